@@ -22,10 +22,13 @@ var destroyCmd = &cobra.Command{
 	Long: `Immediately destroy the ephemeral environment without waiting for TTL
 expiration. This stops any running TTL timer and cleans up all resources.
 
-The environment type is optional - defaults to destroying all environments.`,
-	Example: `  dick destroy                # Destroy all environments
-  dick destroy k8s --force    # Force destroy k8s environment
-  dick destroy kubernetes     # Destroy kubernetes environment`,
+The environment type is optional. Use --name to pick the environment to destroy;
+it defaults to the only environment, or the most recently created active one.`,
+	Example: `  dick destroy                    # Destroy the most recent environment
+  dick destroy --name upgrade     # Destroy the 'upgrade' environment
+  dick destroy k8s --force        # Force destroy k8s environment
+  dick destroy kubernetes         # Destroy kubernetes environment
+  dick destroy --force -o json    # Destroy and print the final record as JSON`,
 	PreRunE: func(cmd *cobra.Command, args []string) error {
 		// Bind destroy command flags with proper namespacing
 		return config.BindDestroyFlags(config.GlobalViper, cmd)
@@ -54,7 +57,9 @@ The environment type is optional - defaults to destroying all environments.`,
 
 		opts := commands.DestroyOptions{
 			Config: cfg,
+			Name:   destroyConfig.Name,
 			Force:  destroyConfig.Force,
+			Output: destroyConfig.Output,
 		}
 		
 		return commands.RunDestroy(opts)
@@ -66,6 +71,11 @@ func init() {
 	
 	// Define flags with modern patterns - no package variables needed
 	destroyCmd.Flags().BoolP("force", "f", false, "Skip confirmation prompt")
+	destroyCmd.Flags().StringP("name", "n", "", "Environment name (defaults to the most recent environment)")
+	destroyCmd.Flags().StringP("output", "o", "", "Output format (text, json, yaml)")
+
+	destroyCmd.RegisterFlagCompletionFunc("name", completeEnvironmentNames)
+	destroyCmd.RegisterFlagCompletionFunc("output", completeOutputFormats)
 	
 	// Add completion for environment types (ValidArgs provides this automatically)
 }
\ No newline at end of file
@@ -0,0 +1,70 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/killallgit/dick/internal/commands"
+	"github.com/killallgit/dick/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var extendCmd = &cobra.Command{
+	Use:   "extend",
+	Short: "Push out the expiry of an environment",
+	Args:  cobra.NoArgs,
+	Long: `Give an active environment more time before automatic TTL cleanup.
+
+Use --by to add a duration to the current expiry, or --until to set an absolute
+expiry. A time of day without a date means its next occurrence. Without either
+flag the environment is extended by its TTL. Scheduled cleanup jobs and running
+monitors pick up the new expiry.`,
+	Example: `  dick extend                     # Extend the most recent environment by its TTL
+  dick extend --by 30m            # Add 30 minutes
+  dick extend --until 18:00       # Keep it until 6pm
+  dick extend --name upgrade --by 1h`,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		// Bind extend command flags with proper namespacing
+		return config.BindExtendFlags(config.GlobalViper, cmd)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Load config using Viper
+		cfg, err := config.LoadConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		extendConfig := cfg.GetEffectiveExtendConfig()
+
+		opts := commands.ExtendOptions{
+			Config: cfg,
+			Name:   extendConfig.Name,
+			By:     extendConfig.By,
+			Until:  extendConfig.Until,
+			Output: extendConfig.Output,
+		}
+
+		// An explicit --by wins over a configured default 'until'
+		if cmd.Flags().Changed("by") {
+			opts.Until = ""
+		}
+
+		return commands.RunExtend(opts)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(extendCmd)
+
+	extendCmd.Flags().String("by", "", "Duration to add to the current expiry (defaults to the TTL)")
+	extendCmd.Flags().String("until", "", "New absolute expiry, e.g. 18:00 or '2025-01-02 18:00'")
+	extendCmd.Flags().StringP("name", "n", "", "Environment name (defaults to the most recent environment)")
+	extendCmd.Flags().StringP("output", "o", "", "Output format (text, json, yaml)")
+	extendCmd.MarkFlagsMutuallyExclusive("by", "until")
+
+	extendCmd.RegisterFlagCompletionFunc("name", completeEnvironmentNames)
+	extendCmd.RegisterFlagCompletionFunc("output", completeOutputFormats)
+}
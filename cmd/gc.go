@@ -0,0 +1,70 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/killallgit/dick/internal/commands"
+	"github.com/killallgit/dick/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var gcCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "Delete resources dick created that no environment owns anymore",
+	Long: `Find the resources dick created and delete those whose environment is
+unknown, destroyed, drifted or expired.
+
+dick marks everything it creates so it can find it later:
+
+  - kind clusters are named after their environment and its ID, with a
+    "dick-" prefix
+  - docker containers carry dev.dick.* labels with the environment ID, name,
+    project and owner. Hooks add them with
+    'docker run --label-file "$DICK_LABEL_FILE"', tofu modules get them as
+    the dick_labels variable.
+
+Resources of an environment that expired or drifted are removed by destroying
+the environment, so its record stays accurate. Environments being created or
+destroyed are left alone. Use --dry-run to only list what would be deleted.
+
+Only resources of the current user are collected: those whose owner label
+names them, and kind clusters of their own environments. Resources of other
+users, e.g. on a shared docker host, and unowned ones no environment of the
+user records, e.g. kind clusters of another checkout, are skipped unless
+--all is given.`,
+	Example: `  dick gc --dry-run   # List orphaned resources
+  dick gc             # Delete them after confirmation
+  dick gc --force     # Delete them without asking, e.g. from cron
+  dick gc --all       # Include other users' and unowned resources`,
+	Args: cobra.NoArgs,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return config.BindGCFlags(config.GlobalViper, cmd)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.LoadConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		gcConfig := cfg.GetEffectiveGCConfig()
+
+		return commands.RunGC(commands.GCOptions{
+			Config: cfg,
+			DryRun: gcConfig.DryRun,
+			Force:  gcConfig.Force,
+			All:    gcConfig.All,
+		})
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(gcCmd)
+
+	gcCmd.Flags().Bool("dry-run", false, "List orphaned resources without deleting them")
+	gcCmd.Flags().BoolP("force", "f", false, "Delete without confirmation")
+	gcCmd.Flags().Bool("all", false, "Also delete resources of other users and unowned resources")
+}
@@ -0,0 +1,100 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+
+*/
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/killallgit/dick/internal/commands"
+	"github.com/killallgit/dick/internal/config"
+	"github.com/killallgit/dick/internal/provider"
+	"github.com/spf13/cobra"
+)
+
+var hooksCmd = &cobra.Command{
+	Use:   "hooks",
+	Short: "Show or eject the hook files providers run",
+	Long: `Providers driven by hook files run the project's own or, when it has none,
+the built-in ones embedded in dick:
+
+  kind  tasks/Taskfile.k8s.yaml, when kind.taskfile is true in .dick.yaml
+  tofu  the root module in tofu/, unless tofu.module points elsewhere
+
+Built-in files are written to .dick/hooks/<provider> and run from there.
+Use 'dick hooks show' to print the files in effect and 'dick hooks eject' to
+copy the built-in ones into the project for customization.`,
+}
+
+var hooksShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Print the hook files in effect for the project",
+	Example: `  dick hooks show              # Hook files of the configured provider
+  dick hooks show -p tofu      # Hook files of the tofu provider`,
+	Args: cobra.NoArgs,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return config.BindHooksFlags(config.GlobalViper, cmd)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		opts, err := hooksOptions()
+		if err != nil {
+			return err
+		}
+		return commands.RunHooksShow(opts)
+	},
+}
+
+var hooksEjectCmd = &cobra.Command{
+	Use:   "eject",
+	Short: "Write the built-in hook files into the project",
+	Long: `Write the built-in hook files of a provider into the project, where they
+take precedence over the built-in ones. Existing files are kept unless
+--force is given.`,
+	Example: `  dick hooks eject             # Eject the hooks of the configured provider
+  dick hooks eject -p kind -f  # Overwrite the project's kind Taskfile`,
+	Args: cobra.NoArgs,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return config.BindHooksFlags(config.GlobalViper, cmd)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		opts, err := hooksOptions()
+		if err != nil {
+			return err
+		}
+		return commands.RunHooksEject(opts)
+	},
+}
+
+// hooksOptions builds the options of the hooks commands from the config
+func hooksOptions() (commands.HooksOptions, error) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return commands.HooksOptions{}, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	hooksConfig := cfg.GetEffectiveHooksConfig()
+	if err := config.ValidateProvider(hooksConfig.Provider); err != nil {
+		return commands.HooksOptions{}, err
+	}
+
+	return commands.HooksOptions{
+		Config:   cfg,
+		Provider: hooksConfig.Provider,
+		Force:    hooksConfig.Force,
+	}, nil
+}
+
+func init() {
+	rootCmd.AddCommand(hooksCmd)
+	hooksCmd.AddCommand(hooksShowCmd, hooksEjectCmd)
+
+	providerUsage := fmt.Sprintf("Provider (%s, defaults to the configured provider)", strings.Join(provider.Names(), ", "))
+	hooksShowCmd.Flags().StringP("provider", "p", "", providerUsage)
+	hooksEjectCmd.Flags().StringP("provider", "p", "", providerUsage)
+	hooksEjectCmd.Flags().BoolP("force", "f", false, "Overwrite hook files the project already has")
+
+	hooksShowCmd.RegisterFlagCompletionFunc("provider", completeProviderNames)
+	hooksEjectCmd.RegisterFlagCompletionFunc("provider", completeProviderNames)
+}
@@ -0,0 +1,45 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+
+*/
+package cmd
+
+import (
+	"github.com/killallgit/dick/internal/commands"
+	"github.com/spf13/cobra"
+)
+
+// initCmd represents the init command
+var initCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Initialize a dick project in the current directory",
+	Long: `Initialize a dick project by writing a .dick.yaml into the current directory.
+
+A wizard asks for the provider, the default TTL and name, and whether expired
+environments are destroyed without asking, then offers to scaffold the
+provider's hook files (tasks/Taskfile.k8s.yaml for kind, a starter tofu/
+module for tofu). Existing hook files are never overwritten unless --force is set.
+
+Use --defaults to skip the wizard, e.g. in scripts and CI. Other commands run
+on the built-in defaults when no .dick.yaml exists and never create one.`,
+	Example: `  dick init                   # Answer a few questions
+  dick init --defaults        # Write the default configuration
+  dick init --force           # Replace an existing .dick.yaml`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		defaults, _ := cmd.Flags().GetBool("defaults")
+		force, _ := cmd.Flags().GetBool("force")
+
+		return commands.RunInit(commands.InitOptions{
+			Defaults: defaults,
+			Force:    force,
+		})
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(initCmd)
+
+	initCmd.Flags().Bool("defaults", false, "Write the default configuration without asking")
+	initCmd.Flags().BoolP("force", "f", false, "Overwrite an existing .dick.yaml and hook files")
+}
@@ -6,9 +6,11 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/killallgit/dick/internal/commands"
 	"github.com/killallgit/dick/internal/config"
+	"github.com/killallgit/dick/internal/provider"
 	"github.com/spf13/cobra"
 )
 
@@ -22,12 +24,85 @@ var newCmd = &cobra.Command{
 after the specified TTL expires. The command stays active showing a real-time 
 dashboard with TTL countdown until the environment is destroyed or you exit.
 
-The environment type is optional - defaults to k8s (Kubernetes via Kind).`,
+Each --name is tracked as its own environment, so several can run side by side.
+The environment type is optional - defaults to k8s (Kubernetes via Kind).
+
+Kind clusters are created by running kind directly. The kind section of
+.dick.yaml sets the node image, control_planes and workers counts and
+port_mappings, or points config at a kind cluster config file. Set
+kind.taskfile to true to run the hooks in tasks/Taskfile.k8s.yaml instead;
+projects without one run the built-in Taskfile ('dick hooks show'). dick runs
+Taskfiles itself, the task CLI need not be installed.
+
+Besides hook:setup and hook:teardown, a Taskfile may define optional lifecycle
+hooks, each run when present:
+
+  hook:pre-create      before the environment is created
+  hook:healthcheck     once the readiness probes passed
+  hook:post-create     after the healthcheck, e.g. to seed data
+  hook:pre-destroy     before the teardown, e.g. to dump logs
+  hook:post-destroy    after the teardown
+  hook:expiry-warning  run by the reaper hooks.expiry_warning.before (5m)
+                       before the environment expires
+
+Hooks get CLUSTER_NAME and the variables listed by 'dick run --help', e.g.
+DICK_ENV_ID and DICK_EXPIRES_AT. They may write key=value outputs to
+$DICK_OUTPUTS_FILE, which 'dick output' prints.
+
+The hooks section of .dick.yaml sets the timeout and on_failure policy of each
+(pre_create, healthcheck, ...): abort fails the create or stops the teardown,
+warn reports the failure and carries on, ignore drops it. Hooks creating the
+environment (pre_create, healthcheck, post_create) abort by default, the
+others warn; post_destroy and expiry_warning can't be set to abort.
+
+A new environment stays provisioning until its readiness probes pass. The
+readiness section of the provider (kind.readiness or tofu.readiness) sets a
+command that must exit 0, an http URL that must answer 2xx/3xx and, for kind,
+nodes to wait for every node to be Ready (the default, needs kubectl), along
+with the timeout (5m) and polling interval (5s). An environment that doesn't
+pass in time is marked failed and destroyed, or kept until its TTL with
+on_failure: keep.
+
+If the create fails or is interrupted with Ctrl+C, whatever was created is
+torn down; --on-create-failure keep (new.on_create_failure in .dick.yaml)
+leaves it for debugging until its TTL instead. Either way the output of the
+provider tools is kept in .dick/logs/<name>/create.log.
+
+The params section of .dick.yaml declares the parameters of an environment,
+each with a type (string, int, bool or duration), a default, a description
+and optional validation: required, enum, pattern (strings) and min/max (ints):
+
+  params:
+    k8s_version:
+      description: Kubernetes version of the nodes
+      default: v1.31.0
+      pattern: ^v1\.[0-9]+\.[0-9]+$
+    workers:
+      type: int
+      default: 1
+      min: 0
+      max: 5
+
+--values reads name: value pairs from YAML files and --set name=value
+overrides them; unknown or invalid values are rejected before anything is
+created. The resolved values are recorded with the environment and shown by
+'dick status'. Hooks and Taskfiles see them as DICK_PARAM_<NAME>, tofu modules
+as TF_VAR_<name> (overriding tofu.vars of the same name) and kind expands
+${name} in kind.image and kind.config, e.g. image: kindest/node:${k8s_version}.
+
+Use --detach to exit once the environment is active instead of showing the
+dashboard; the background reaper and the scheduled cleanup job enforce the TTL.
+With --output json or --output yaml, progress goes to stderr, the new
+environment is written to stdout and the command exits the same way.`,
 	ValidArgs: []string{"k8s", "kubernetes"},
 	Example: `  dick new                    # Create k8s cluster with 5m TTL, then watch
   dick new k8s --ttl 10m      # Create with 10 minute TTL, then watch
   dick new --name my-cluster  # Create with custom name, then watch
-  dick new --force --ttl 30m  # Force new config and watch`,
+  dick new --force --ttl 30m  # Force new config and watch
+  dick new --set workers=3    # Create with a parameter declared in .dick.yaml
+  dick new --values ci.yaml   # Create with parameter values from a file
+  dick new --detach           # Create, print a summary and exit
+  dick new -o json            # Create, print the environment as JSON and exit`,
 	Args: cobra.MaximumNArgs(1),
 	PreRunE: func(cmd *cobra.Command, args []string) error {
 		return config.BindNewFlags(config.GlobalViper, cmd)
@@ -58,8 +133,11 @@ The environment type is optional - defaults to k8s (Kubernetes via Kind).`,
 			Provider: newConfig.Provider,
 			TTL:      newConfig.TTL,
 			Name:     newConfig.Name,
-			Wait:     true, // Always watch by default
+			Wait:     !newConfig.Detach,
 			Force:    newConfig.Force,
+			Output:   newConfig.Output,
+			Set:      newConfig.Set,
+			Values:   newConfig.Values,
 		}
 		
 		return commands.RunNew(opts)
@@ -71,10 +149,55 @@ func init() {
 
 	newCmd.Flags().StringP("ttl", "t", "", "Time to live (e.g., 5m, 10m, 1h)")
 	newCmd.Flags().StringP("name", "n", "", "Environment name")  
-	newCmd.Flags().StringP("provider", "p", "", "Infrastructure provider (kind, tofu)")
+	newCmd.Flags().StringP("provider", "p", "", fmt.Sprintf("Infrastructure provider (%s)", strings.Join(provider.Names(), ", ")))
 	newCmd.Flags().BoolP("force", "f", false, "Force overwrite existing config with defaults and provided args")
+	newCmd.Flags().StringP("output", "o", "", "Output format (text, json, yaml); structured formats skip the dashboard")
+	newCmd.Flags().BoolP("detach", "d", false, "Exit after creating instead of showing the dashboard")
+	newCmd.Flags().String("on-create-failure", "", "What to do with a failed or interrupted create: destroy or keep (default destroy)")
+	newCmd.Flags().StringArray("set", nil, "Set a parameter declared in .dick.yaml (name=value, repeatable)")
+	newCmd.Flags().StringArray("values", nil, "YAML file of parameter values (repeatable, later files win)")
 
 	newCmd.RegisterFlagCompletionFunc("ttl", cobra.FixedCompletions([]string{"5m", "10m", "30m", "1h", "2h"}, cobra.ShellCompDirectiveDefault))
-	newCmd.RegisterFlagCompletionFunc("provider", cobra.FixedCompletions([]string{"kind", "tofu"}, cobra.ShellCompDirectiveDefault))
+	newCmd.RegisterFlagCompletionFunc("provider", completeProviderNames)
+	newCmd.RegisterFlagCompletionFunc("output", completeOutputFormats)
+	newCmd.RegisterFlagCompletionFunc("on-create-failure", completeFailurePolicies)
+	newCmd.RegisterFlagCompletionFunc("set", completeParamNames)
+	newCmd.RegisterFlagCompletionFunc("values", cobra.FixedCompletions([]string{"yaml", "yml"}, cobra.ShellCompDirectiveFilterFileExt))
+}
+
+// completeParamNames completes --set flags with the parameters declared in
+// .dick.yaml, described by their description or type
+func completeParamNames(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var names []cobra.Completion
+	for name, param := range cfg.Params {
+		description := param.Description
+		if description == "" {
+			description = param.Type
+		}
+		names = append(names, cobra.CompletionWithDesc(name+"=", description))
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+}
+
+// completeFailurePolicies completes flags choosing what happens to an
+// environment that failed to come up
+func completeFailurePolicies(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	return []cobra.Completion{
+		cobra.CompletionWithDesc("destroy", "Tear down whatever was created"),
+		cobra.CompletionWithDesc("keep", "Keep it for debugging until its TTL expires"),
+	}, cobra.ShellCompDirectiveNoFileComp
+}
 
+// completeProviderNames completes --provider flags with the registered providers
+func completeProviderNames(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	var names []cobra.Completion
+	for _, p := range provider.All() {
+		names = append(names, cobra.CompletionWithDesc(p.Name(), p.Description()))
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
 }
\ No newline at end of file
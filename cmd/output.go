@@ -0,0 +1,85 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/killallgit/dick/internal/commands"
+	"github.com/killallgit/dick/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var outputCmd = &cobra.Command{
+	Use:   "output [key]",
+	Short: "Print the outputs of an environment",
+	Long: `Print the values an environment exposes: those of its provider, e.g. the
+kind kubeconfig path or tofu outputs, and those its hooks wrote.
+
+Hooks write outputs as key=value lines to $DICK_OUTPUTS_FILE and may keep
+generated files in $DICK_OUTPUTS_DIR. dick reads the file after the create
+and again after the post-create hook; hook outputs override provider outputs
+of the same key.
+
+Without a key every output is printed as key=value, with a key only its value.`,
+	Example: `  dick output                        # All outputs of the most recent environment
+  dick output kubeconfig --name e2e  # One value
+  export INGRESS_URL=$(dick output ingress_url)`,
+	Args: cobra.MaximumNArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return config.BindOutputFlags(config.GlobalViper, cmd)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.LoadConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		opts := commands.OutputOptions{
+			Config: cfg,
+			Name:   cfg.GetEffectiveOutputConfig().Name,
+		}
+		if len(args) == 1 {
+			opts.Key = args[0]
+		}
+
+		return commands.RunOutput(opts)
+	},
+	ValidArgsFunction: completeOutputKeys,
+}
+
+func init() {
+	rootCmd.AddCommand(outputCmd)
+
+	outputCmd.Flags().StringP("name", "n", "", "Environment name (defaults to the most recent environment)")
+
+	outputCmd.RegisterFlagCompletionFunc("name", completeEnvironmentNames)
+}
+
+// completeOutputKeys completes the output keys of the environment picked by --name
+func completeOutputKeys(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	if err := initConfig(cmd); err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	name, _ := cmd.Flags().GetString("name")
+	env, err := cfg.ResolveEnvironment(name)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var keys []cobra.Completion
+	for key, value := range env.Outputs {
+		keys = append(keys, cobra.CompletionWithDesc(key, value))
+	}
+	return keys, cobra.ShellCompDirectiveNoFileComp
+}
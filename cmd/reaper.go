@@ -0,0 +1,75 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+
+*/
+package cmd
+
+import (
+	"github.com/killallgit/dick/internal/commands"
+	"github.com/killallgit/dick/internal/common"
+	"github.com/spf13/cobra"
+)
+
+var reaperCmd = &cobra.Command{
+	Use:   "reaper",
+	Short: "Destroy expired environments in the background",
+	Long: `Run the reaper, a per-user background process that destroys environments
+once their TTL expires, even after the dick command that created them has exited.
+
+'dick new' starts the reaper automatically. Only one reaper runs per user; it
+watches every project with active environments and exits once none remain.
+Running 'dick reaper' directly keeps it in the foreground, logging to stderr.`,
+	Example: `  dick reaper                     # Run the reaper in the foreground
+  dick reaper --stay-alive        # Keep running when no environments remain
+  dick reaper start               # Start the reaper in the background
+  dick reaper status              # Show the reaper and the environments it watches
+  dick reaper stop                # Stop the background reaper`,
+	Args: cobra.NoArgs,
+	// The reaper is project-independent and must not read or create
+	// a .dick.yaml in whatever directory it was started from
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		common.VerboseFlag = verbose
+		common.SilentFlag = silent
+		// The reaper runs unattended and must never prompt
+		common.NoInputFlag = true
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		stayAlive, _ := cmd.Flags().GetBool("stay-alive")
+		return commands.RunReaper(commands.ReaperOptions{StayAlive: stayAlive})
+	},
+}
+
+var reaperStartCmd = &cobra.Command{
+	Use:   "start",
+	Short: "Start the reaper in the background",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return commands.RunReaperStart()
+	},
+}
+
+var reaperStopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Stop the background reaper",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return commands.RunReaperStop()
+	},
+}
+
+var reaperStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the reaper and the environments it watches",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return commands.RunReaperStatus()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(reaperCmd)
+	reaperCmd.AddCommand(reaperStartCmd, reaperStopCmd, reaperStatusCmd)
+
+	reaperCmd.Flags().Bool("stay-alive", false, "Keep running when no active environments remain")
+}
@@ -0,0 +1,63 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/killallgit/dick/internal/commands"
+	"github.com/killallgit/dick/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var reconcileCmd = &cobra.Command{
+	Use:   "reconcile [name]",
+	Short: "Repair environment records that drifted from their infrastructure",
+	Long: `Ask each environment's provider whether its infrastructure exists and
+repair the records that disagree:
+
+  - an environment whose infrastructure is gone, e.g. a kind cluster deleted
+    by hand, is marked destroyed and its scheduled cleanup is cancelled
+  - a destroyed environment whose infrastructure still exists is marked
+    drifted; its TTL applies again, so it is torn down at its recorded
+    expiry, or 'dick destroy' tears it down right away. Environments
+    recorded before dick gave them IDs are never marked drifted, since a
+    same-named cluster need not be theirs.
+
+Environments another dick process is creating or destroying are skipped, as
+are providers that can't tell whether the infrastructure exists (taskfile).
+'dick status' runs the same check before showing anything.`,
+	Example: `  dick reconcile              # Check every environment
+  dick reconcile e2e          # Check the 'e2e' environment
+  dick reconcile -o json      # Print the checked records as JSON`,
+	Args: cobra.MaximumNArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return config.BindReconcileFlags(config.GlobalViper, cmd)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.LoadConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		opts := commands.ReconcileOptions{
+			Output: cfg.GetEffectiveReconcileConfig().Output,
+		}
+		if len(args) == 1 {
+			opts.Name = args[0]
+		}
+
+		return commands.RunReconcile(opts)
+	},
+	ValidArgsFunction: completeEnvironmentArgs,
+}
+
+func init() {
+	rootCmd.AddCommand(reconcileCmd)
+
+	reconcileCmd.Flags().StringP("output", "o", "", "Output format (text, json, yaml)")
+
+	reconcileCmd.RegisterFlagCompletionFunc("output", completeOutputFormats)
+}
@@ -11,12 +11,15 @@ import (
 	
 	"github.com/killallgit/dick/internal/common"
 	"github.com/killallgit/dick/internal/config"
+	"github.com/killallgit/dick/internal/output"
 )
 
 var (
 	cfgFile string
 	verbose bool
 	silent  bool
+	yes     bool
+	noInput bool
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -26,24 +29,84 @@ var rootCmd = &cobra.Command{
 	Long: `Dick creates temporary cloud environments that automatically self-destruct
 after a specified time period, preventing resource waste and cost overruns.
 
-Currently supports Kubernetes clusters via Kind with automatic cleanup scheduling.
+Currently supports Kubernetes clusters via Kind and OpenTofu modules with automatic
+cleanup scheduling.
+Each project can run several named environments side by side, and a background
+reaper destroys them when their TTL expires even after dick has exited.
+If dick is killed while creating or destroying an environment, the next status,
+new or destroy command finds the interrupted operation and offers to resume or
+roll it back.
 
 Environment variables (with command namespacing):
   Global flags:
     DICK_GLOBAL_VERBOSE=true         - Enable verbose output
     DICK_GLOBAL_SILENT=true          - Enable silent output
+    DICK_GLOBAL_YES=true             - Answer yes to all prompts
+    DICK_GLOBAL_NO_INPUT=true        - Never prompt
 
   New command flags:
     DICK_NEW_PROVIDER=kind           - Default provider (kind, tofu)
     DICK_NEW_TTL=5m                  - Default TTL for new environments
     DICK_NEW_NAME=dev-cluster        - Default cluster/environment name
     DICK_NEW_FORCE=true              - Force overwrite config
+    DICK_NEW_OUTPUT=json             - Output format (text, json, yaml)
+    DICK_NEW_DETACH=true             - Exit after creating instead of watching
+    DICK_NEW_ON_CREATE_FAILURE=keep  - Keep failed creates (destroy, keep)
+    DICK_NEW_SET=workers=3,debug=1   - Parameter assignments (comma-separated)
+    DICK_NEW_VALUES=params.yaml      - Parameter values files (comma-separated)
 
   Status command flags:
     DICK_STATUS_WATCH=true           - Watch status by default
+    DICK_STATUS_CMD_NAME=dev-cluster - Environment to show
+    DICK_STATUS_CMD_OUTPUT=json      - Output format (text, json, yaml)
+    DICK_STATUS_CMD_RECONCILE=false  - Skip checking environments for drift
 
   Destroy command flags:
     DICK_DESTROY_FORCE=true          - Skip confirmation prompts
+    DICK_DESTROY_NAME=dev-cluster    - Environment to destroy
+    DICK_DESTROY_OUTPUT=json         - Output format (text, json, yaml)
+
+  Extend command flags:
+    DICK_EXTEND_BY=30m               - Default extension (defaults to the TTL)
+    DICK_EXTEND_NAME=dev-cluster     - Environment to extend
+    DICK_EXTEND_OUTPUT=json          - Output format (text, json, yaml)
+
+  Run command flags (environment flags share the DICK_NEW_* settings):
+    DICK_RUN_KEEP_ON_FAILURE=true    - Keep the environment if the command fails
+
+  Wait command flags:
+    DICK_WAIT_FOR=ready              - Condition (ready, destroyed, expiring=5m)
+    DICK_WAIT_TIMEOUT=10m            - Give up after this long (default: never)
+
+  Reconcile command flags:
+    DICK_RECONCILE_OUTPUT=json       - Output format (text, json, yaml)
+
+  Output command flags:
+    DICK_OUTPUT_CMD_NAME=dev-cluster - Environment whose outputs to print
+
+  Hooks command flags:
+    DICK_HOOKS_CMD_PROVIDER=tofu     - Provider whose hooks to show or eject
+    DICK_HOOKS_CMD_FORCE=true        - Overwrite the project's hook files
+
+  GC command flags:
+    DICK_GC_DRY_RUN=true             - Only list orphaned resources
+    DICK_GC_FORCE=true               - Delete without confirmation
+    DICK_GC_ALL=true                 - Also delete other users' and unowned resources
+
+  Cleanup settings:
+    DICK_CLEANUP_SCHEDULER=auto      - OS scheduler backing up the reaper
+                                       (auto, none, at, systemd, cron, schtasks)
+
+  Prompts:
+    Without a terminal, or with --no-input, prompts are answered from the
+    prompts section of .dick.yaml and the answer is logged to stderr:
+    DICK_PROMPTS_DESTROY_EXPIRED=true - Destroy expired clusters found by any command
+    DICK_PROMPTS_DESTROY=false       - Let 'dick destroy' proceed without --force/--yes
+    DICK_PROMPTS_RESUME_CREATE=false - Finish an interrupted 'dick new' instead of
+                                       rolling it back
+    DICK_PROMPTS_RESUME_DESTROY=true - Finish an interrupted teardown instead of
+                                       keeping the environment
+    DICK_PROMPTS_GC=false            - Let 'dick gc' proceed without --force/--yes
 
   Legacy environment variables (deprecated but supported):
     DICK_PROVIDER, DICK_TTL, DICK_NAME, DICK_FORCE`,
@@ -59,6 +122,10 @@ Environment variables (with command namespacing):
 			return fmt.Errorf("failed to initialize config: %w", err)
 		}
 		
+		// Prompt answers may also come from the environment or config file
+		common.YesFlag = config.GlobalViper.GetBool("global.yes")
+		common.NoInputFlag = config.GlobalViper.GetBool("global.no_input")
+		
 		return nil
 	},
 }
@@ -76,9 +143,12 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default searches for .dick.yaml)")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output for task commands")
 	rootCmd.PersistentFlags().BoolVar(&silent, "silent", false, "silent output for task commands")
+	rootCmd.PersistentFlags().BoolVarP(&yes, "yes", "y", false, "answer yes to all prompts")
+	rootCmd.PersistentFlags().BoolVar(&noInput, "no-input", false, "never prompt; use the configured answers (see prompts in .dick.yaml)")
 	
 	// Mark flags as mutually exclusive
 	rootCmd.MarkFlagsMutuallyExclusive("verbose", "silent")
+	rootCmd.MarkFlagsMutuallyExclusive("yes", "no-input")
 	
 	// Add completion for config flag to suggest YAML files
 	rootCmd.RegisterFlagCompletionFunc("config", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
@@ -102,4 +172,37 @@ func initConfig(cmd *cobra.Command) error {
 	return nil
 }
 
+// completeEnvironmentNames completes --name flags with the registered environments
+func completeEnvironmentNames(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	if err := initConfig(cmd); err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var names []cobra.Completion
+	for _, env := range cfg.ListEnvironments() {
+		names = append(names, cobra.CompletionWithDesc(env.Name, env.Status))
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeOutputFormats completes --output flags with the supported formats
+func completeOutputFormats(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	var formats []cobra.Completion
+	for _, f := range output.Formats {
+		formats = append(formats, string(f))
+	}
+	return formats, cobra.ShellCompDirectiveNoFileComp
+}
 
+// completeEnvironmentArgs completes a single environment name argument
+func completeEnvironmentArgs(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completeEnvironmentNames(cmd, args, toComplete)
+}
@@ -0,0 +1,98 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/killallgit/dick/internal/commands"
+	"github.com/killallgit/dick/internal/config"
+	"github.com/killallgit/dick/internal/provider"
+	"github.com/spf13/cobra"
+)
+
+var runCmd = &cobra.Command{
+	Use:   "run [flags] -- <command> [args...]",
+	Short: "Run a command against a fresh environment, then destroy it",
+	Long: `Create an ephemeral environment, run a command against it and destroy the
+environment when the command exits. No dashboard is shown: dick reports on
+stderr and the command keeps stdout, stdin and stderr.
+
+The command gets the same variables as the hooks: DICK_ENV_NAME, DICK_ENV_ID,
+DICK_PROVIDER, DICK_TTL, DICK_EXPIRES_AT, DICK_PROJECT_PATH, DICK_LABEL_FILE,
+DICK_OUTPUTS_DIR, DICK_OUTPUTS_FILE, DICK_PARAM_<NAME> for each parameter and
+DICK_OUTPUT_<KEY> for each output, plus KUBECONFIG when an output provides a
+kubeconfig.
+Interrupt and terminate signals are forwarded to it. dick exits with the
+command's status, so it can wrap test suites in CI.
+
+With --keep-on-failure a failing command leaves the environment running for
+debugging. The TTL stays in force either way, and destroys the environment if
+dick itself is killed.`,
+	Example: `  dick run --ttl 30m -- go test ./e2e/...
+  dick run --name e2e --keep-on-failure -- make integration
+  dick run -- kubectl get nodes`,
+	Args: cobra.MinimumNArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return config.BindRunFlags(config.GlobalViper, cmd)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.LoadConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		if err := config.ValidateConfig(cfg); err != nil {
+			return fmt.Errorf("invalid configuration: %w", err)
+		}
+
+		newConfig := cfg.GetEffectiveNewConfig()
+		runConfig := cfg.GetEffectiveRunConfig()
+
+		opts := commands.RunOptions{
+			Config:        cfg,
+			Provider:      newConfig.Provider,
+			TTL:           newConfig.TTL,
+			Name:          newConfig.Name,
+			Set:           newConfig.Set,
+			Values:        newConfig.Values,
+			KeepOnFailure: runConfig.KeepOnFailure,
+			Command:       args,
+		}
+
+		err = commands.RunRun(opts)
+
+		// The command reported its own failure, only pass on its status
+		var exitErr *commands.ExitError
+		if errors.As(err, &exitErr) {
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+		}
+		return err
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(runCmd)
+
+	// Flags after -- belong to the command
+	runCmd.Flags().SetInterspersed(false)
+
+	runCmd.Flags().StringP("ttl", "t", "", "Time to live, a backstop if dick is killed (e.g., 30m, 1h)")
+	runCmd.Flags().StringP("name", "n", "", "Environment name")
+	runCmd.Flags().StringP("provider", "p", "", fmt.Sprintf("Infrastructure provider (%s)", strings.Join(provider.Names(), ", ")))
+	runCmd.Flags().Bool("keep-on-failure", false, "Keep the environment if the command fails")
+	runCmd.Flags().String("on-create-failure", "", "What to do with a failed or interrupted create: destroy or keep (default destroy)")
+	runCmd.Flags().StringArray("set", nil, "Set a parameter declared in .dick.yaml (name=value, repeatable)")
+	runCmd.Flags().StringArray("values", nil, "YAML file of parameter values (repeatable, later files win)")
+
+	runCmd.RegisterFlagCompletionFunc("ttl", cobra.FixedCompletions([]string{"10m", "30m", "1h", "2h"}, cobra.ShellCompDirectiveDefault))
+	runCmd.RegisterFlagCompletionFunc("provider", completeProviderNames)
+	runCmd.RegisterFlagCompletionFunc("on-create-failure", completeFailurePolicies)
+	runCmd.RegisterFlagCompletionFunc("set", completeParamNames)
+	runCmd.RegisterFlagCompletionFunc("values", cobra.FixedCompletions([]string{"yaml", "yml"}, cobra.ShellCompDirectiveFilterFileExt))
+}
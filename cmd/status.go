@@ -19,13 +19,23 @@ var statusCmd = &cobra.Command{
 	Short:     "Show environment status and remaining TTL",
 	ValidArgs: []string{"k8s", "kubernetes"},
 	Args:      cobra.MaximumNArgs(1), // Use built-in validator instead of custom function
-	Long: `Display the current status of your ephemeral environment including
+	Long: `Display the current status of your ephemeral environments including
 remaining time before automatic TTL cleanup.
 
-The environment type is optional - defaults to showing status for all environments.`,
+The environment type is optional - defaults to showing status for all environments.
+Use --name to show a single environment; watch mode defaults to the most recent one.
+
+Use --output json or --output yaml for a versioned, machine readable document
+listing each environment's ID, name, provider, status, created and expiry times,
+remaining seconds, cleanup attempts and last cleanup error.
+
+Before showing anything, status reconciles the environments with their
+providers like 'dick reconcile'; pass --reconcile=false to skip it.`,
 	Example: `  dick status                 # Show status for all environments
+  dick status --name upgrade  # Show status for the 'upgrade' environment
   dick status k8s --watch     # Watch k8s environment status
-  dick status kubernetes      # Show kubernetes environment status`,
+  dick status kubernetes      # Show kubernetes environment status
+  dick status -o json         # Machine readable status for scripts`,
 	PreRunE: func(cmd *cobra.Command, args []string) error {
 		// Bind status command flags with proper namespacing
 		return config.BindStatusFlags(config.GlobalViper, cmd)
@@ -53,8 +63,11 @@ The environment type is optional - defaults to showing status for all environmen
 		}
 
 		opts := commands.StatusOptions{
-			Config: cfg,
-			Watch:  statusConfig.Watch,
+			Config:    cfg,
+			Name:      statusConfig.Name,
+			Watch:     statusConfig.Watch,
+			Output:    statusConfig.Output,
+			Reconcile: statusConfig.Reconcile,
 		}
 		
 		return commands.RunStatus(opts)
@@ -66,6 +79,12 @@ func init() {
 	
 	// Define flags with modern patterns - no package variables needed
 	statusCmd.Flags().BoolP("watch", "w", false, "Watch environment status with live updates")
+	statusCmd.Flags().StringP("name", "n", "", "Environment name (defaults to all, or the most recent when watching)")
+	statusCmd.Flags().StringP("output", "o", "", "Output format (text, json, yaml)")
+	statusCmd.Flags().Bool("reconcile", true, "Check the environments against their providers and repair drift first")
+
+	statusCmd.RegisterFlagCompletionFunc("name", completeEnvironmentNames)
+	statusCmd.RegisterFlagCompletionFunc("output", completeOutputFormats)
 	
 	// Add completion for environment types (ValidArgs provides this automatically)
 }
\ No newline at end of file
@@ -0,0 +1,94 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/killallgit/dick/internal/commands"
+	"github.com/killallgit/dick/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var waitCmd = &cobra.Command{
+	Use:   "wait [name]",
+	Short: "Block until an environment reaches a lifecycle state",
+	Long: `Wait until an environment meets a condition, polling its state:
+
+  ready            the environment passed its readiness probes and is active
+                   (and, with --probe, its provider reports the infrastructure
+                   running)
+  destroyed        the environment has been destroyed
+  expiring=<dur>   the environment expires within the duration, e.g. expiring=5m
+
+Without a name, dick waits on the most recent active environment, or for
+ready, on the first one to become active. Waiting for ready, a destroyed or
+failed record left by an earlier run counts as not created yet, so
+'dick new --detach && dick wait --for ready' works for a reused name.
+
+Exit codes:
+  0  the condition was met
+  1  an error occurred
+  2  the timeout passed first
+  3  the environment reached a state the condition can't be met from, e.g.
+     it failed its readiness probes or was destroyed while waiting for ready`,
+	Example: `  dick wait --for ready --timeout 10m e2e
+  dick wait --for destroyed
+  dick wait --for expiring=5m && notify-send "cluster expires soon"`,
+	Args: cobra.MaximumNArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return config.BindWaitFlags(config.GlobalViper, cmd)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.LoadConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		waitConfig := cfg.GetEffectiveWaitConfig()
+
+		opts := commands.WaitOptions{
+			For:   waitConfig.For,
+			Probe: waitConfig.Probe,
+		}
+		if len(args) == 1 {
+			opts.Name = args[0]
+		}
+		if waitConfig.Timeout != "" {
+			if opts.Timeout, err = time.ParseDuration(waitConfig.Timeout); err != nil {
+				return fmt.Errorf("invalid timeout '%s': %w", waitConfig.Timeout, err)
+			}
+		}
+		if waitConfig.Interval != "" {
+			if opts.Interval, err = time.ParseDuration(waitConfig.Interval); err != nil {
+				return fmt.Errorf("invalid interval '%s': %w", waitConfig.Interval, err)
+			}
+		}
+
+		err = commands.RunWait(opts)
+
+		// Timeouts and failed states were reported, only pass on the exit code
+		var exitErr *commands.ExitError
+		if errors.As(err, &exitErr) {
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+		}
+		return err
+	},
+	ValidArgsFunction: completeEnvironmentArgs,
+}
+
+func init() {
+	rootCmd.AddCommand(waitCmd)
+
+	waitCmd.Flags().String("for", "", "Condition to wait for: ready, destroyed or expiring=<duration> (default ready)")
+	waitCmd.Flags().String("timeout", "", "Give up after this long, e.g. 10m (default: wait forever)")
+	waitCmd.Flags().String("interval", "", "How often to poll the state (default 2s)")
+	waitCmd.Flags().Bool("probe", false, "For ready, also ask the provider whether the infrastructure is running")
+
+	waitCmd.RegisterFlagCompletionFunc("for", cobra.FixedCompletions([]string{"ready", "destroyed", "expiring=5m"}, cobra.ShellCompDirectiveNoFileComp))
+}
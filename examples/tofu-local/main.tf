@@ -0,0 +1,36 @@
+# A local-only module for trying the tofu provider without a cloud account.
+# It only uses the built-in terraform_data resource, so `tofu init` needs no
+# provider downloads. Point tofu.module in .dick.yaml at this directory.
+
+variable "dick_env_name" {
+  type        = string
+  description = "Name of the dick environment (set by dick)"
+}
+
+variable "dick_ttl" {
+  type        = string
+  description = "TTL of the dick environment (set by dick)"
+  default     = ""
+}
+
+variable "greeting" {
+  type        = string
+  description = "Example variable, set it with tofu.vars in .dick.yaml"
+  default     = "hello"
+}
+
+resource "terraform_data" "environment" {
+  input = {
+    name     = var.dick_env_name
+    ttl      = var.dick_ttl
+    greeting = var.greeting
+  }
+}
+
+output "environment_id" {
+  value = terraform_data.environment.id
+}
+
+output "message" {
+  value = "${var.greeting} from ${var.dick_env_name}"
+}
@@ -1,6 +1,7 @@
 package cleanup
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"time"
@@ -9,66 +10,61 @@ import (
 	"github.com/killallgit/dick/internal/tui"
 )
 
-// CheckAndHandleExpiration checks for expired clusters and handles them appropriately
-func CheckAndHandleExpiration(cfg *config.Config) (bool, error) {
+// CheckAndHandleExpiration checks an environment for expiration and handles it appropriately
+func CheckAndHandleExpiration(cfg *config.Config, env *config.Environment) (bool, error) {
 	// Check if we should attempt any cleanup (including retries)
-	if !cfg.ShouldAttemptCleanup() && !cfg.ShouldRetryCleanup() {
+	if !env.ShouldAttemptCleanup() && !env.ShouldRetryCleanup() {
 		return false, nil // Not expired, not active, or no retry needed
 	}
 
-	_, expiredSince := cfg.CheckExpiration()
+	_, expiredSince := env.CheckExpiration()
 	
 	// Handle expired cluster based on configuration
-	if cfg.ShouldAutoDestroy() || (cfg.ShouldRetryCleanup() && cfg.Force) {
+	if env.ShouldAutoDestroy(cfg.Force) || (env.ShouldRetryCleanup() && cfg.Force) {
 		// Force mode: automatic cleanup without prompt
-		return handleAutoDestroy(cfg, expiredSince)
-	} else if cfg.ShouldPromptDestroy() || cfg.ShouldRetryCleanup() {
+		return handleAutoDestroy(cfg, env, expiredSince)
+	} else if env.ShouldPromptDestroy(cfg.Force) || env.ShouldRetryCleanup() {
 		// Interactive mode: prompt user for cleanup (or retry)
-		return handlePromptDestroy(cfg, expiredSince)
+		return handlePromptDestroy(cfg, env, expiredSince)
 	} else {
 		// Expired cluster with cleanup attempts exhausted - show detailed status
 		fmt.Printf("%s Cluster '%s' expired %s ago - cleanup attempts exhausted\n",
 			tui.Icon("warning"),
-			tui.InfoValueStyle.Render(cfg.Name),
+			tui.InfoValueStyle.Render(env.Name),
 			tui.InfoValueStyle.Render(expiredSince.String()))
 		fmt.Printf("%s Cleanup status: %s\n",
 			tui.Icon("info"),
-			tui.InfoValueStyle.Render(cfg.GetCleanupStatus()))
-		fmt.Printf("%s Run 'dick destroy --force' to manually cleanup\n",
-			tui.Icon("info"))
+			tui.InfoValueStyle.Render(env.GetCleanupStatus()))
+		fmt.Printf("%s Run 'dick destroy --force --name %s' to manually cleanup\n",
+			tui.Icon("info"), env.Name)
 		return false, nil
 	}
 }
 
 // handleAutoDestroy performs automatic cleanup in force mode
-func handleAutoDestroy(cfg *config.Config, expiredSince time.Duration) (bool, error) {
+func handleAutoDestroy(cfg *config.Config, env *config.Environment, expiredSince time.Duration) (bool, error) {
 	retryText := ""
-	if cfg.CleanupAttempts > 0 {
-		retryText = fmt.Sprintf(" (retry %d)", cfg.CleanupAttempts+1)
+	if env.CleanupAttempts > 0 {
+		retryText = fmt.Sprintf(" (retry %d)", env.CleanupAttempts+1)
 	}
 	
-	fmt.Printf("%s Cluster expired %s ago. Auto-destroying (force mode)%s...\n",
+	fmt.Printf("%s Cluster '%s' expired %s ago. Auto-destroying (force mode)%s...\n",
 		tui.Icon("warning"),
+		tui.InfoValueStyle.Render(env.Name),
 		tui.InfoValueStyle.Render(expiredSince.String()),
 		retryText)
 
-	// Perform cleanup
-	err := ForceCleanup(cfg)
-	
-	// Update cleanup state based on result
+	// Perform cleanup, which records the attempt
+	err := performCleanup(env, true)
+	if errors.Is(err, errCleanupNotDue) {
+		fmt.Printf("%s Cluster '%s' was extended or destroyed in the meantime\n",
+			tui.Icon("info"), tui.InfoValueStyle.Render(env.Name))
+		return false, nil
+	}
 	if err != nil {
-		cfg.MarkCleanupFailed(err)
-		if saveErr := config.SaveConfig(cfg); saveErr != nil {
-			log.Printf("Warning: failed to save config after cleanup failure: %v", saveErr)
-		}
 		return false, fmt.Errorf("automatic cleanup failed: %w", err)
 	}
 
-	cfg.MarkCleanupSuccessful()
-	if saveErr := config.SaveConfig(cfg); saveErr != nil {
-		log.Printf("Warning: failed to save config after successful cleanup: %v", saveErr)
-	}
-
 	fmt.Printf("%s %s\n",
 		tui.Icon("success"),
 		tui.SuccessStyle.Render("Cluster automatically destroyed"))
@@ -78,22 +74,24 @@ func handleAutoDestroy(cfg *config.Config, expiredSince time.Duration) (bool, er
 
 // CheckExpirationForCommand handles expired cluster checking for any command
 func CheckExpirationForCommand(cfg *config.Config) error {
-	// Only check active clusters
-	if cfg.Status != "active" {
-		return nil
+	var errs []error
+
+	// Only check active environments
+	for _, env := range cfg.ActiveEnvironments() {
+		if _, err := CheckAndHandleExpiration(cfg, env); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", env.Name, err))
+		}
 	}
 
-	// Check and handle expiration
-	_, err := CheckAndHandleExpiration(cfg)
-	return err
+	return errors.Join(errs...)
 }
 
 // handlePromptDestroy prompts user for cleanup confirmation  
-func handlePromptDestroy(cfg *config.Config, expiredSince time.Duration) (bool, error) {
+func handlePromptDestroy(cfg *config.Config, env *config.Environment, expiredSince time.Duration) (bool, error) {
 	title := "Cluster Expired"
 	retryText := ""
-	if cfg.CleanupAttempts > 0 {
-		retryText = fmt.Sprintf("\n\nPrevious cleanup attempts: %s", cfg.GetCleanupStatus())
+	if env.CleanupAttempts > 0 {
+		retryText = fmt.Sprintf("\n\nPrevious cleanup attempts: %s", env.GetCleanupStatus())
 	}
 	
 	message := fmt.Sprintf(
@@ -102,34 +100,31 @@ func handlePromptDestroy(cfg *config.Config, expiredSince time.Duration) (bool,
 			"• Yes: Destroy the cluster immediately\n"+
 			"• No: Keep the cluster (you can destroy it manually later)\n\n"+
 			"Note: You can set 'force: true' in .dick.yaml to auto-destroy expired clusters.%s",
-		cfg.Name,
+		env.Name,
 		expiredSince.String(),
 		retryText)
 
-	confirmed, err := tui.RunConfirmation(title, message)
+	confirmed, err := tui.Confirm(title, message, cfg.Prompts.DestroyExpired)
 	if err != nil {
 		return false, fmt.Errorf("failed to show confirmation: %w", err)
 	}
 
 	if confirmed {
 		// User confirmed cleanup
-		fmt.Printf("%s Destroying expired cluster...\n",
-			tui.Icon("destroy"))
-
-		err := ForceCleanup(cfg)
+		fmt.Printf("%s Destroying expired cluster %s...\n",
+			tui.Icon("destroy"),
+			tui.InfoValueStyle.Render(env.Name))
+
+		err := performCleanup(env, true)
+		if errors.Is(err, errCleanupNotDue) {
+			fmt.Printf("%s Cluster '%s' was extended or destroyed in the meantime\n",
+				tui.Icon("info"), tui.InfoValueStyle.Render(env.Name))
+			return false, nil
+		}
 		if err != nil {
-			cfg.MarkCleanupFailed(err)
-			if saveErr := config.SaveConfig(cfg); saveErr != nil {
-				log.Printf("Warning: failed to save config after cleanup failure: %v", saveErr)
-			}
 			return false, fmt.Errorf("cleanup failed: %w", err)
 		}
 
-		cfg.MarkCleanupSuccessful()
-		if saveErr := config.SaveConfig(cfg); saveErr != nil {
-			log.Printf("Warning: failed to save config after successful cleanup: %v", saveErr)
-		}
-
 		fmt.Printf("%s %s\n",
 			tui.Icon("success"),
 			tui.SuccessStyle.Render("Expired cluster destroyed"))
@@ -137,9 +132,15 @@ func handlePromptDestroy(cfg *config.Config, expiredSince time.Duration) (bool,
 		return true, nil
 	} else {
 		// User declined cleanup - record attempt
-		cfg.MarkCleanupAttempted()
-		if err := config.SaveConfig(cfg); err != nil {
-			log.Printf("Warning: failed to save config: %v", err)
+		expiresAt := env.ExpiresAt
+		if err := env.Update(func(current *config.Environment) error {
+			// An extension meanwhile starts a fresh set of attempts
+			if current.ExpiresAt.Equal(expiresAt) {
+				current.MarkCleanupAttempted()
+			}
+			return nil
+		}); err != nil {
+			log.Printf("Warning: failed to save state: %v", err)
 		}
 		
 		fmt.Printf("%s Cleanup cancelled. Cluster remains active.\n",
@@ -0,0 +1,16 @@
+//go:build !windows
+
+package cleanup
+
+import "syscall"
+
+// detachedProcAttr starts a child in its own session so it survives the CLI
+// and the terminal it was started from
+func detachedProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{Setsid: true}
+}
+
+// terminateProcess asks a process to exit gracefully
+func terminateProcess(pid int) error {
+	return syscall.Kill(pid, syscall.SIGTERM)
+}
@@ -0,0 +1,27 @@
+//go:build windows
+
+package cleanup
+
+import (
+	"os"
+	"syscall"
+
+	"golang.org/x/sys/windows"
+)
+
+// detachedProcAttr starts a child without a console in its own process group
+// so it survives the CLI and the terminal it was started from
+func detachedProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{
+		CreationFlags: windows.DETACHED_PROCESS | windows.CREATE_NEW_PROCESS_GROUP,
+	}
+}
+
+// terminateProcess stops a process; Windows has no graceful equivalent of SIGTERM
+func terminateProcess(pid int) error {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return err
+	}
+	return process.Kill()
+}
@@ -0,0 +1,327 @@
+package cleanup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/killallgit/dick/internal/config"
+	"github.com/killallgit/dick/internal/provider"
+)
+
+const (
+	// reaperPollInterval is the longest the reaper sleeps between scans
+	reaperPollInterval = 30 * time.Second
+
+	// reaperLockName is held by the running reaper for its whole lifetime
+	reaperLockName = "reaper.lock"
+
+	// reaperPidName records the PID of the running reaper
+	reaperPidName = "reaper.pid"
+
+	// reaperLogName receives the output of a reaper started in the background
+	reaperLogName = "reaper.log"
+)
+
+// ErrReaperRunning is returned when another reaper already owns this user
+var ErrReaperRunning = errors.New("reaper is already running")
+
+// ReaperOptions controls the reaper loop
+type ReaperOptions struct {
+	// StayAlive keeps the reaper running when no active environments remain
+	StayAlive bool
+}
+
+// ReaperStatus describes the reaper of the current user
+type ReaperStatus struct {
+	Running bool
+	PID     int
+	LogPath string
+}
+
+// RunReaper enforces the TTL of every known environment until ctx is done.
+// Only one reaper runs per user; it exits once no active environments remain
+// unless StayAlive is set.
+func RunReaper(ctx context.Context, opts ReaperOptions) error {
+	dir, err := config.UserStateDir()
+	if err != nil {
+		return err
+	}
+
+	lock, err := config.TryLockFile(filepath.Join(dir, reaperLockName))
+	if errors.Is(err, config.ErrLocked) {
+		return ErrReaperRunning
+	}
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	pidPath := filepath.Join(dir, reaperPidName)
+	if err := os.WriteFile(pidPath, []byte(strconv.Itoa(os.Getpid())+"\n"), 0o644); err != nil {
+		return fmt.Errorf("failed to write reaper pid file: %w", err)
+	}
+	defer os.Remove(pidPath)
+
+	log.Printf("Reaper started (pid %d)", os.Getpid())
+
+	for {
+		active, nextEvent := reapOnce()
+		if active == 0 && !opts.StayAlive {
+			log.Printf("No active environments left, reaper exiting")
+			return nil
+		}
+
+		// Wake up for the next expiry or expiry warning, but rescan
+		// regularly to pick up new environments and extended TTLs
+		wait := reaperPollInterval
+		if !nextEvent.IsZero() {
+			if untilEvent := time.Until(nextEvent); untilEvent < wait {
+				wait = untilEvent
+			}
+		}
+		if wait < time.Second {
+			wait = time.Second
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Printf("Reaper stopped")
+			return nil
+		case <-time.After(wait):
+		}
+	}
+}
+
+// reapOnce destroys every expired environment of every known project and
+// warns about those expiring soon. It returns the number of environments
+// still active and the time of the earliest expiry or expiry warning.
+func reapOnce() (active int, nextEvent time.Time) {
+	projects, err := config.KnownProjects()
+	if err != nil {
+		log.Printf("Failed to read project index: %v", err)
+		return 0, time.Time{}
+	}
+
+	for _, projectPath := range projects {
+		if _, err := os.Stat(projectPath); errors.Is(err, os.ErrNotExist) {
+			log.Printf("Project %s no longer exists, forgetting it", projectPath)
+			config.UnregisterProject(projectPath)
+			continue
+		}
+
+		cfg, err := config.LoadProjectConfig(projectPath)
+		if err != nil {
+			log.Printf("Failed to load project %s: %v", projectPath, err)
+			continue
+		}
+
+		projectActive := 0
+		for _, env := range cfg.ListEnvironments() {
+			if !env.HoldsResources() {
+				continue
+			}
+			if env.ShouldAttemptCleanup() || env.ShouldRetryCleanup() {
+				reapEnvironment(env)
+			}
+
+			if env.HoldsResources() {
+				projectActive++
+				if !env.IsExpired() && (nextEvent.IsZero() || env.ExpiresAt.Before(nextEvent)) {
+					nextEvent = env.ExpiresAt
+				}
+			}
+			if warning := warnExpiry(env); !warning.IsZero() && (nextEvent.IsZero() || warning.Before(nextEvent)) {
+				nextEvent = warning
+			}
+		}
+
+		// The count above is from before the cleanups; a 'dick new' may
+		// have registered an environment since
+		if projectActive == 0 {
+			if err := config.UnregisterIdleProject(projectPath); err != nil {
+				log.Printf("Failed to unregister project %s: %v", projectPath, err)
+			}
+		}
+		active += projectActive
+	}
+
+	return active, nextEvent
+}
+
+// warnExpiry runs the expiry-warning hook of an active environment once it
+// expires within hooks.expiry_warning.before, once per expiry. It returns
+// when the warning is due, or zero when there is none to wait for.
+func warnExpiry(env *config.Environment) time.Time {
+	if !env.IsActive() || env.IsExpired() || env.WarnedExpiry.Equal(env.ExpiresAt) {
+		return time.Time{}
+	}
+
+	// The environment may run with another configuration file than its project
+	envCfg, err := config.LoadEnvironmentConfig(env)
+	if err != nil {
+		log.Printf("Failed to load configuration of '%s': %v", env.Name, err)
+		return time.Time{}
+	}
+	before, err := time.ParseDuration(envCfg.Hooks.ExpiryWarning.Before)
+	if err != nil || before <= 0 {
+		return time.Time{}
+	}
+	if due := env.ExpiresAt.Add(-before); time.Now().Before(due) {
+		return due
+	}
+
+	// Skip environments another process is working on until the next scan
+	lock, err := config.LockEnvironment(env.ProjectPath, env.Name)
+	if err != nil {
+		return time.Time{}
+	}
+	defer lock.Unlock()
+
+	// 'dick extend' may have pushed the expiry out since the scan started
+	current, err := config.ReadEnvironment(env.ProjectPath, env.Name)
+	if err != nil || current == nil || !current.ExpiresAt.Equal(env.ExpiresAt) {
+		return time.Time{}
+	}
+	*env = *current
+
+	p, err := provider.Get(env.Provider)
+	if err != nil {
+		log.Printf("Failed to warn about expiry of '%s': %v", env.Name, err)
+		return time.Time{}
+	}
+
+	log.Printf("Environment '%s' in %s expires at %s, running expiry-warning hook",
+		env.Name, env.ProjectPath, env.ExpiresAt.Format(time.RFC3339))
+	if err := provider.RunHook(context.Background(), p, env, provider.HookExpiryWarning); err != nil {
+		log.Printf("Warning: cluster '%s': %v", env.Name, err)
+	}
+
+	warned := env.ExpiresAt
+	if err := env.Update(func(current *config.Environment) error {
+		current.WarnedExpiry = warned
+		return nil
+	}); err != nil {
+		log.Printf("Failed to save state of '%s': %v", env.Name, err)
+	}
+	return time.Time{}
+}
+
+// reapEnvironment destroys one expired environment, unless it was extended or
+// destroyed since it was found expired
+func reapEnvironment(env *config.Environment) {
+	log.Printf("Environment '%s' in %s expired at %s",
+		env.Name, env.ProjectPath, env.ExpiresAt.Format(time.RFC3339))
+
+	err := performCleanup(env, true)
+	switch {
+	case errors.Is(err, ErrCleanupInProgress):
+		log.Printf("Environment '%s' is locked by another process, skipping", env.Name)
+	case errors.Is(err, errCleanupNotDue):
+		log.Printf("Environment '%s' was extended or destroyed in the meantime, skipping", env.Name)
+	case err != nil:
+		log.Printf("Failed to destroy '%s': %v", env.Name, err)
+	default:
+		log.Printf("Environment '%s' destroyed after TTL expiration", env.Name)
+	}
+}
+
+// EnsureReaper starts a background reaper for the current user unless one is
+// already running
+func EnsureReaper() error {
+	status, err := GetReaperStatus()
+	if err != nil {
+		return err
+	}
+	if status.Running {
+		return nil
+	}
+
+	dickPath, err := getDickExecutablePath()
+	if err != nil {
+		return fmt.Errorf("failed to get dick executable path: %w", err)
+	}
+
+	logFile, err := os.OpenFile(status.LogPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open reaper log: %w", err)
+	}
+	defer logFile.Close()
+
+	cmd := exec.Command(dickPath, "reaper")
+	cmd.Dir = filepath.Dir(status.LogPath)
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	cmd.SysProcAttr = detachedProcAttr()
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start reaper: %w", err)
+	}
+
+	// The reaper outlives us; don't wait for it
+	pid := cmd.Process.Pid
+	if err := cmd.Process.Release(); err != nil {
+		return err
+	}
+
+	// Wait until it holds its lock, so callers can rely on it running
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if current, err := GetReaperStatus(); err == nil && current.Running {
+			return nil
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	return fmt.Errorf("reaper (pid %d) did not start, see %s", pid, status.LogPath)
+}
+
+// StopReaper asks the running reaper to exit
+func StopReaper() error {
+	status, err := GetReaperStatus()
+	if err != nil {
+		return err
+	}
+	if !status.Running {
+		return nil
+	}
+	if status.PID == 0 {
+		return fmt.Errorf("reaper is running but its pid is unknown")
+	}
+
+	return terminateProcess(status.PID)
+}
+
+// GetReaperStatus reports whether a reaper is running for the current user
+func GetReaperStatus() (ReaperStatus, error) {
+	dir, err := config.UserStateDir()
+	if err != nil {
+		return ReaperStatus{}, err
+	}
+
+	status := ReaperStatus{LogPath: filepath.Join(dir, reaperLogName)}
+
+	// The reaper holds its lock for as long as it runs
+	lock, err := config.TryLockFile(filepath.Join(dir, reaperLockName))
+	if errors.Is(err, config.ErrLocked) {
+		status.Running = true
+	} else if err != nil {
+		return status, err
+	} else {
+		lock.Unlock()
+	}
+
+	if status.Running {
+		if data, err := os.ReadFile(filepath.Join(dir, reaperPidName)); err == nil {
+			status.PID, _ = strconv.Atoi(strings.TrimSpace(string(data)))
+		}
+	}
+
+	return status, nil
+}
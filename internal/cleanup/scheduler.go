@@ -0,0 +1,174 @@
+package cleanup
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/killallgit/dick/internal/config"
+)
+
+const (
+	// SchedulerAuto picks the first available scheduler for the platform
+	SchedulerAuto = "auto"
+
+	// SchedulerNone disables out-of-process cleanup scheduling
+	SchedulerNone = "none"
+)
+
+// Scheduler schedules a one-shot cleanup job with an OS-level job scheduler,
+// so an environment is destroyed even if neither dick nor its reaper is running
+type Scheduler interface {
+	// Name returns the name used to select the scheduler in .dick.yaml
+	Name() string
+
+	// Available reports whether the scheduler can be used on this machine
+	Available() bool
+
+	// Schedule registers the job and returns an ID for Cancel and Exists
+	Schedule(job CleanupJob) (string, error)
+
+	// Cancel removes a scheduled job
+	Cancel(jobID string) error
+
+	// Exists reports whether a scheduled job is still pending
+	Exists(jobID string) (bool, error)
+}
+
+// CleanupJob describes a scheduled destroy of a single environment
+type CleanupJob struct {
+	DickPath   string
+	ProjectDir string
+	EnvName    string
+	EnvID      string
+	At         time.Time
+}
+
+// Args returns the dick command line that destroys the environment
+func (j CleanupJob) Args() []string {
+	return []string{j.DickPath, "destroy", "--force", "--name", j.EnvName}
+}
+
+// ShellCommand returns the job as a shell command run from the project directory
+func (j CleanupJob) ShellCommand() string {
+	args := make([]string, 0, len(j.Args()))
+	for _, arg := range j.Args() {
+		args = append(args, shellEscape(arg))
+	}
+	return fmt.Sprintf("cd %s && %s", shellEscape(j.ProjectDir), strings.Join(args, " "))
+}
+
+// unitName returns a name identifying the job in the backend's job list. It
+// is made of the environment ID, which is safe in systemd unit and schtasks
+// task names; environments recorded before IDs fall back to their name with
+// everything but letters, digits and '-' hex-escaped.
+func (j CleanupJob) unitName() string {
+	key := j.EnvID
+	if key == "" {
+		key = escapeUnitName(j.EnvName)
+	}
+	return fmt.Sprintf("dick-cleanup-%s-%d", key, time.Now().Unix())
+}
+
+// escapeUnitName replaces the bytes of name that aren't ASCII letters,
+// digits or '-' with _xx, their hex value
+func escapeUnitName(name string) string {
+	var b strings.Builder
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '-':
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "_%02x", c)
+		}
+	}
+	return b.String()
+}
+
+// schedulers holds every known scheduler backend keyed by name
+var schedulers = map[string]Scheduler{
+	"at":       atScheduler{},
+	"systemd":  systemdScheduler{},
+	"cron":     cronScheduler{},
+	"schtasks": schtasksScheduler{},
+}
+
+func init() {
+	// Let config validate scheduler names without importing the backends
+	config.SetSchedulerNames(SchedulerNames)
+}
+
+// autoDetectOrder lists the schedulers tried by auto-detection, best first
+func autoDetectOrder() []string {
+	switch runtime.GOOS {
+	case "linux":
+		return []string{"systemd", "at", "cron"}
+	case "darwin":
+		return []string{"at", "cron"}
+	case "windows":
+		return []string{"schtasks"}
+	default:
+		return []string{"at", "cron"}
+	}
+}
+
+// SchedulerNames returns the names accepted by the cleanup.scheduler setting
+func SchedulerNames() []string {
+	names := []string{SchedulerAuto, SchedulerNone}
+	backends := make([]string, 0, len(schedulers))
+	for name := range schedulers {
+		backends = append(backends, name)
+	}
+	sort.Strings(backends)
+	return append(names, backends...)
+}
+
+// GetScheduler returns the named scheduler. "auto" (or an empty name) picks the
+// first available scheduler for this platform; "none" returns nil.
+func GetScheduler(name string) (Scheduler, error) {
+	switch name {
+	case SchedulerNone:
+		return nil, nil
+	case "", SchedulerAuto:
+		for _, candidate := range autoDetectOrder() {
+			if s := schedulers[candidate]; s.Available() {
+				return s, nil
+			}
+		}
+		return nil, fmt.Errorf("no cleanup scheduler available (tried: %s)", strings.Join(autoDetectOrder(), ", "))
+	}
+
+	s, ok := schedulers[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown cleanup scheduler '%s' (supported: %s)", name, strings.Join(SchedulerNames(), ", "))
+	}
+	if !s.Available() {
+		return nil, fmt.Errorf("cleanup scheduler '%s' is not available on this machine", name)
+	}
+	return s, nil
+}
+
+// commandExists reports whether all given commands are on the PATH
+func commandExists(names ...string) bool {
+	for _, name := range names {
+		if _, err := exec.LookPath(name); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// jobPath returns the PATH scheduled jobs run with, so that the tools hooks
+// call, e.g. kind and docker, are found outside of the user's login shell.
+// Taskfiles themselves run inside dick.
+func jobPath() string {
+	if path := os.Getenv("PATH"); path != "" {
+		return path
+	}
+	return "/usr/local/bin:/usr/bin:/bin"
+}
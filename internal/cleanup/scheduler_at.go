@@ -0,0 +1,94 @@
+package cleanup
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// atScheduler schedules cleanup with the 'at' command (macOS/Linux)
+type atScheduler struct{}
+
+func (atScheduler) Name() string { return "at" }
+
+func (atScheduler) Available() bool {
+	return commandExists("at", "atq", "atrm")
+}
+
+func (atScheduler) Schedule(job CleanupJob) (string, error) {
+	// 'at' only has minute precision; never fire before the expiry
+	runAt := roundUpToMinute(job.At)
+
+	// Format time for 'at' command: "HH:MM MM/DD/YY"
+	atTime := runAt.Format("15:04 01/02/06")
+
+	command := job.ShellCommand() + " 2>&1 | logger -t dick-cleanup"
+
+	// Execute: echo "command" | at time
+	cmd := exec.Command("at", atTime)
+	cmd.Stdin = strings.NewReader(command)
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("failed to schedule with 'at' command at time %s: %w, output: %s", atTime, err, string(output))
+	}
+
+	// Extract job ID from output (format: "job 123 at ...")
+	jobID := extractAtJobID(string(output))
+	if jobID == "" {
+		return "", fmt.Errorf("failed to extract job ID from 'at' output: %s", string(output))
+	}
+
+	return jobID, nil
+}
+
+func (atScheduler) Cancel(jobID string) error {
+	cmd := exec.Command("atrm", jobID)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to cancel 'at' job %s: %w, output: %s", jobID, err, string(output))
+	}
+	return nil
+}
+
+func (atScheduler) Exists(jobID string) (bool, error) {
+	cmd := exec.Command("atq")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return false, fmt.Errorf("failed to list 'at' jobs: %w", err)
+	}
+
+	// The job ID is the first field of each atq line
+	for _, line := range strings.Split(string(output), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) > 0 && fields[0] == jobID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// extractAtJobID extracts job ID from 'at' command output
+func extractAtJobID(output string) string {
+	lines := strings.Split(output, "\n")
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "job ") {
+			parts := strings.Fields(line)
+			if len(parts) >= 2 {
+				return parts[1] // Return the job number
+			}
+		}
+	}
+	return ""
+}
+
+// roundUpToMinute returns t, or the start of the next minute if t is not on a minute boundary
+func roundUpToMinute(t time.Time) time.Time {
+	rounded := t.Truncate(time.Minute)
+	if rounded.Before(t) {
+		rounded = rounded.Add(time.Minute)
+	}
+	return rounded
+}
@@ -0,0 +1,149 @@
+package cleanup
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/killallgit/dick/internal/config"
+)
+
+// cronMarker precedes the job ID that ends every crontab line owned by dick
+const cronMarker = "# "
+
+// cronScheduler schedules cleanup with a one-shot entry in the user's crontab
+type cronScheduler struct{}
+
+func (cronScheduler) Name() string { return "cron" }
+
+func (cronScheduler) Available() bool {
+	return commandExists("crontab")
+}
+
+func (cronScheduler) Schedule(job CleanupJob) (string, error) {
+	jobID := job.unitName()
+	marker := cronMarker + jobID
+
+	// cron only has minute precision; never fire before the expiry
+	runAt := roundUpToMinute(job.At).Local()
+
+	// The entry removes itself after running so it doesn't fire again next year
+	command := fmt.Sprintf("PATH=%s; export PATH; %s; crontab -l | grep -v -F %s | crontab -",
+		shellEscape(jobPath()), job.ShellCommand(), shellEscape(marker))
+
+	line := fmt.Sprintf("%d %d %d %d * %s %s",
+		runAt.Minute(), runAt.Hour(), runAt.Day(), int(runAt.Month()),
+		escapeCronCommand(command), marker)
+
+	lock, err := lockCrontab()
+	if err != nil {
+		return "", err
+	}
+	defer lock.Unlock()
+
+	lines, err := readCrontab()
+	if err != nil {
+		return "", err
+	}
+	if err := writeCrontab(append(lines, line)); err != nil {
+		return "", err
+	}
+
+	return jobID, nil
+}
+
+func (cronScheduler) Cancel(jobID string) error {
+	lock, err := lockCrontab()
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	lines, err := readCrontab()
+	if err != nil {
+		return err
+	}
+
+	kept := lines[:0]
+	for _, line := range lines {
+		if !strings.HasSuffix(line, cronMarker+jobID) {
+			kept = append(kept, line)
+		}
+	}
+
+	return writeCrontab(kept)
+}
+
+func (cronScheduler) Exists(jobID string) (bool, error) {
+	lines, err := readCrontab()
+	if err != nil {
+		return false, err
+	}
+
+	for _, line := range lines {
+		if strings.HasSuffix(line, cronMarker+jobID) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// lockCrontab serializes the read-modify-write cycles dick processes of the
+// user make on the crontab, so none drops an entry another one just added.
+// The self-removal of an entry that ran can't take the lock, but it is a
+// fallback: the cleanup it runs normally cancels the entry under the lock
+// first.
+func lockCrontab() (*config.FileLock, error) {
+	dir, err := config.UserStateDir()
+	if err != nil {
+		return nil, err
+	}
+	return config.LockFile(filepath.Join(dir, "crontab.lock"))
+}
+
+// readCrontab returns the lines of the user's crontab, empty if there is none
+func readCrontab() ([]string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("crontab", "-l")
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && strings.Contains(stderr.String(), "no crontab") {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read crontab: %w, output: %s", err, stderr.String())
+	}
+
+	var lines []string
+	for _, line := range strings.Split(stdout.String(), "\n") {
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, nil
+}
+
+// writeCrontab replaces the user's crontab with the given lines
+func writeCrontab(lines []string) error {
+	content := strings.Join(lines, "\n")
+	if content != "" {
+		content += "\n"
+	}
+
+	cmd := exec.Command("crontab", "-")
+	cmd.Stdin = strings.NewReader(content)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to write crontab: %w, output: %s", err, string(output))
+	}
+	return nil
+}
+
+// escapeCronCommand escapes characters cron treats specially in the command field
+func escapeCronCommand(command string) string {
+	return strings.ReplaceAll(command, "%", `\%`)
+}
@@ -0,0 +1,72 @@
+package cleanup
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// schtasksScheduler schedules cleanup with 'schtasks' (Windows)
+type schtasksScheduler struct{}
+
+func (schtasksScheduler) Name() string { return "schtasks" }
+
+func (schtasksScheduler) Available() bool {
+	return commandExists("schtasks")
+}
+
+func (schtasksScheduler) Schedule(job CleanupJob) (string, error) {
+	taskName := job.unitName()
+
+	// schtasks only has minute precision; never fire before the expiry
+	runAt := roundUpToMinute(job.At).Local()
+	scheduleTime := runAt.Format("15:04")
+	scheduleDate := runAt.Format("01/02/2006")
+
+	// cmd strips the outer quotes and keeps the inner ones, so paths with
+	// spaces or '&' stay whole
+	command := fmt.Sprintf(`cmd /c "cd /d %s && %s destroy --force --name %s"`,
+		cmdQuote(job.ProjectDir), cmdQuote(job.DickPath), cmdQuote(job.EnvName))
+
+	cmd := exec.Command("schtasks", "/create", "/tn", taskName, "/tr", command,
+		"/sc", "once", "/st", scheduleTime, "/sd", scheduleDate)
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("failed to schedule with 'schtasks': %w, output: %s", err, string(output))
+	}
+
+	return taskName, nil
+}
+
+func (schtasksScheduler) Cancel(taskName string) error {
+	cmd := exec.Command("schtasks", "/delete", "/tn", taskName, "/f")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to cancel scheduled task %s: %w, output: %s", taskName, err, string(output))
+	}
+	return nil
+}
+
+func (schtasksScheduler) Exists(taskName string) (bool, error) {
+	// Querying a single task fails alike for a missing task and a broken
+	// schtasks, so look for it in the list of all tasks
+	cmd := exec.Command("schtasks", "/query", "/fo", "csv", "/nh")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return false, fmt.Errorf("failed to list scheduled tasks: %w, output: %s", err, string(output))
+	}
+
+	// The task name, with its folder, is the first field of each line
+	for _, line := range strings.Split(string(output), "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), `"\`+taskName+`"`) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// cmdQuote quotes a path for cmd. Windows paths can't contain quotes.
+func cmdQuote(s string) string {
+	return `"` + s + `"`
+}
@@ -0,0 +1,66 @@
+package cleanup
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+)
+
+// systemdScheduler schedules cleanup with a transient systemd user timer
+type systemdScheduler struct{}
+
+func (systemdScheduler) Name() string { return "systemd" }
+
+func (systemdScheduler) Available() bool {
+	if !commandExists("systemd-run", "systemctl") {
+		return false
+	}
+
+	// Transient user units need a running user manager
+	return exec.Command("systemctl", "--user", "show-environment").Run() == nil
+}
+
+func (systemdScheduler) Schedule(job CleanupJob) (string, error) {
+	unit := job.unitName()
+
+	args := []string{
+		"--user",
+		"--unit=" + unit,
+		"--description=dick cleanup of " + job.EnvName,
+		"--on-calendar=" + job.At.Local().Format("2006-01-02 15:04:05"),
+		"--timer-property=AccuracySec=1s",
+		"--working-directory=" + job.ProjectDir,
+		"--setenv=PATH=" + jobPath(),
+		"--collect",
+	}
+	args = append(args, job.Args()...)
+
+	output, err := exec.Command("systemd-run", args...).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("failed to schedule with 'systemd-run': %w, output: %s", err, string(output))
+	}
+
+	return unit, nil
+}
+
+func (systemdScheduler) Cancel(jobID string) error {
+	output, err := exec.Command("systemctl", "--user", "stop", jobID+".timer").CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to stop timer %s: %w, output: %s", jobID, err, string(output))
+	}
+	return nil
+}
+
+func (systemdScheduler) Exists(jobID string) (bool, error) {
+	err := exec.Command("systemctl", "--user", "is-active", "--quiet", jobID+".timer").Run()
+	if err == nil {
+		return true, nil
+	}
+
+	// is-active exits non-zero for inactive and unknown units
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return false, nil
+	}
+	return false, fmt.Errorf("failed to query timer %s: %w", jobID, err)
+}
@@ -1,51 +1,84 @@
 package cleanup
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
-	"os/exec"
-	"path/filepath"
-	"strings"
 	"time"
 
-	"github.com/killallgit/dick/internal/common"
 	"github.com/killallgit/dick/internal/config"
+	"github.com/killallgit/dick/internal/provider"
 )
 
-// StartTTLTimer starts a background timer that will cleanup the cluster when TTL expires
-func StartTTLTimer(cfg *config.Config) error {
-	if cfg.Status != "active" {
-		return fmt.Errorf("cluster is not active, cannot start TTL timer")
+// ErrCleanupInProgress is returned when another process is already destroying the environment
+var ErrCleanupInProgress = errors.New("cleanup already in progress in another process")
+
+// errCleanupNotDue is returned when an expired environment was extended or
+// destroyed between being found expired and being locked for its cleanup
+var errCleanupNotDue = errors.New("cleanup no longer due")
+
+// StartTTLTimer starts a background timer that will cleanup the environment
+// when TTL expires. The timer works on its own copy of env, so callers read
+// the outcome from the state store.
+func StartTTLTimer(cfg *config.Config, env *config.Environment) error {
+	if env.Status != "active" {
+		return fmt.Errorf("cluster %s is not active, cannot start TTL timer", env.Name)
 	}
 
-	duration := cfg.TimeRemaining()
+	duration := env.TimeRemaining()
 	if duration <= 0 {
 		return fmt.Errorf("cluster has already expired")
 	}
 
 	// Start the timer in a goroutine
+	env = env.Clone()
 	go func() {
 		log.Printf("TTL timer started: cluster will be destroyed in %v", duration)
-		
-		// Wait for the TTL to expire
-		time.Sleep(duration)
-		
+
+		// Sleep until the expiry, then re-read it since 'dick extend' may
+		// have pushed it out in the meantime
+		for {
+			time.Sleep(duration)
+
+			latest, err := config.ReadEnvironment(env.ProjectPath, env.Name)
+			if err != nil {
+				log.Printf("Failed to read state of cluster '%s': %v", env.Name, err)
+				return
+			}
+			if latest == nil || !latest.IsActive() {
+				return
+			}
+
+			duration = latest.TimeRemaining()
+			if duration <= 0 {
+				break
+			}
+			log.Printf("Cluster '%s' was extended, TTL timer now expires in %v", env.Name, duration)
+		}
+
 		// Perform cleanup
-		if err := performCleanup(cfg); err != nil {
-			log.Printf("Failed to cleanup cluster: %v", err)
+		if err := performCleanup(env, true); errors.Is(err, errCleanupNotDue) {
+			log.Printf("Cluster '%s' was extended or destroyed in the meantime", env.Name)
+		} else if err != nil {
+			log.Printf("Failed to cleanup cluster '%s': %v", env.Name, err)
 		} else {
-			log.Printf("Cluster '%s' destroyed after TTL expiration", cfg.Name)
+			log.Printf("Cluster '%s' destroyed after TTL expiration", env.Name)
 		}
 	}()
 
 	return nil
 }
 
-// performCleanup executes the cleanup task and updates state
-func performCleanup(cfg *config.Config) error {
+// performCleanup destroys an environment while holding its lock. The record
+// is re-read under the lock: the cleanup of an expired environment goes ahead
+// only if it is still expired and due, so one extended or destroyed since it
+// was found expired survives, and its outcome is recorded as a cleanup attempt.
+// The scheduled cleanup job is cancelled once the teardown is certain.
+func performCleanup(env *config.Environment, expired bool) error {
 	// Get the project directory where .dick.yaml is located
-	projectDir := cfg.ProjectPath
+	projectDir := env.ProjectPath
 	if projectDir == "" {
 		// Fallback to current directory
 		pwd, err := os.Getwd()
@@ -53,102 +86,121 @@ func performCleanup(cfg *config.Config) error {
 			return fmt.Errorf("failed to get current directory: %w", err)
 		}
 		projectDir = pwd
+		env.ProjectPath = pwd
 	}
 
-	// Execute the destroy task using provider-specific taskfile
-	var taskFile string
-	switch cfg.Provider {
-	case "kind":
-		taskFile = filepath.Join(projectDir, "tasks", "Taskfile.k8s.yaml")
-	default:
-		// Fallback to legacy taskfile
-		taskFile = filepath.Join(projectDir, "tasks", "Taskfile.new.yaml")
-	}
-	
-	// Check if new taskfile exists, fallback to legacy if needed
-	if _, err := os.Stat(taskFile); err != nil {
-		// Fallback to legacy taskfile for backward compatibility
-		taskFile = filepath.Join(projectDir, "tasks", "Taskfile.new.yaml")
+	// Own the environment for the duration of the teardown so a TTL timer,
+	// the monitor and a manual destroy can never tear it down twice
+	lock, err := config.LockEnvironment(projectDir, env.Name)
+	if errors.Is(err, config.ErrLocked) {
+		return fmt.Errorf("cluster %s: %w", env.Name, ErrCleanupInProgress)
 	}
-	
-	if err := executeDestroyTask(taskFile, cfg.Name, cfg.Provider); err != nil {
-		return fmt.Errorf("failed to execute destroy task: %w", err)
+	if err != nil {
+		return err
 	}
+	defer lock.Unlock()
 
-	// Update the config to mark as destroyed
-	cfg.SetDestroyed()
-	
-	// Change to project directory to save config
-	originalDir, err := os.Getwd()
+	// Another process may have changed the environment before we got the lock
+	current, err := config.ReadEnvironment(projectDir, env.Name)
 	if err != nil {
-		return fmt.Errorf("failed to get current directory: %w", err)
+		return err
 	}
-	
-	if err := os.Chdir(projectDir); err != nil {
-		return fmt.Errorf("failed to change to project directory: %w", err)
+	if current == nil {
+		return fmt.Errorf("cluster %s is unknown", env.Name)
 	}
-	defer os.Chdir(originalDir)
+	*env = *current
 
-	if err := config.SaveConfig(cfg); err != nil {
-		return fmt.Errorf("failed to update config: %w", err)
+	if expired {
+		if !env.ShouldAttemptCleanup() && !env.ShouldRetryCleanup() {
+			return errCleanupNotDue
+		}
+	} else if env.Status == "destroyed" {
+		// Another process finished the job
+		return nil
+	} else if !env.HoldsResources() {
+		return fmt.Errorf("cluster %s is not active", env.Name)
 	}
 
-	return nil
-}
+	if err := CancelScheduledCleanup(env); err != nil {
+		log.Printf("Warning: failed to cancel scheduled cleanup of '%s': %v", env.Name, err)
+	}
 
-// executeDestroyTask runs the kind:destroy task using the task command
-func executeDestroyTask(taskFile, clusterName, provider string) error {
-	// Check if task command is available
-	if _, err := exec.LookPath("task"); err != nil {
-		return fmt.Errorf("task command not found: %w", err)
-	}
-
-	// Execute: task -t <taskfile> hook:teardown CLUSTER_NAME=<name>
-	taskArgs := []string{"-t", taskFile}
-	
-	// Add --silent flag by default unless verbose mode is enabled
-	if !common.ShouldShowTaskOutput() {
-		taskArgs = append(taskArgs, "--silent")
-	}
-	
-	// Use standardized hook:teardown task, fallback to provider-specific task
-	var taskName string
-	if strings.Contains(taskFile, "Taskfile.k8s.yaml") {
-		taskName = "hook:teardown"
-	} else {
-		// Legacy taskfile - use provider-specific task name
-		switch provider {
-		case "kind":
-			taskName = "kind:destroy"
-		default:
-			taskName = "hook:teardown" // Try standardized hook as fallback
+	err = DestroyLocked(env)
+	if expired {
+		if saveErr := env.Update(func(current *config.Environment) error {
+			if err != nil {
+				current.MarkCleanupFailed(err)
+			} else {
+				current.MarkCleanupSuccessful()
+			}
+			return nil
+		}); saveErr != nil {
+			log.Printf("Failed to save state of '%s': %v", env.Name, saveErr)
 		}
 	}
-	
-	taskArgs = append(taskArgs, taskName, fmt.Sprintf("CLUSTER_NAME=%s", clusterName))
-	cmd := exec.Command("task", taskArgs...)
-	
-	// Set the working directory to the project directory
-	cmd.Dir = filepath.Dir(filepath.Dir(taskFile))
-	
-	// Always capture output for background cleanup operations
-	output, err := cmd.CombinedOutput()
+	return err
+}
+
+// DestroyLocked tears down an environment whose lock the caller holds. The
+// record says destroying while the provider works, so a crash mid-teardown
+// is detected later; a failed teardown restores the previous status.
+func DestroyLocked(env *config.Environment) error {
+	p, err := provider.Get(env.Provider)
 	if err != nil {
-		return fmt.Errorf("task execution failed: %w, output: %s", err, string(output))
+		return err
 	}
 
-	// Log output only in verbose mode for background operations
-	if common.ShouldShowTaskOutput() {
-		log.Printf("Task output: %s", string(output))
+	// Hooks see the environment before it is torn down, e.g. to dump logs
+	if err := provider.RunHook(context.Background(), p, env, provider.HookPreDestroy); err != nil {
+		if provider.IsAbort(err) {
+			return err
+		}
+		log.Printf("Warning: cluster '%s': %v", env.Name, err)
+	}
+
+	previous := env.Status
+	if err := env.Update(func(current *config.Environment) error {
+		current.SetDestroying()
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to update state: %w", err)
 	}
+
+	if err := p.Destroy(context.Background(), env); err != nil {
+		if saveErr := env.Update(func(current *config.Environment) error {
+			if previous == "destroying" {
+				// Resuming an interrupted teardown, the environment was active
+				// or failed before; either way it is broken now
+				current.SetFailed(fmt.Sprintf("teardown failed: %v", err))
+			} else {
+				current.Status = previous
+			}
+			return nil
+		}); saveErr != nil {
+			log.Printf("Failed to restore state of '%s': %v", env.Name, saveErr)
+		}
+		return fmt.Errorf("failed to destroy with provider %s: %w", p.Name(), err)
+	}
+
+	// Update the environment record to mark as destroyed
+	if err := env.Update(func(current *config.Environment) error {
+		current.SetDestroyed()
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to update state: %w", err)
+	}
+
+	if err := provider.RunHook(context.Background(), p, env, provider.HookPostDestroy); err != nil {
+		log.Printf("Warning: cluster '%s': %v", env.Name, err)
+	}
+
+	// Files handed to the hooks are of no use anymore; outputs stay recorded
+	os.Remove(provider.LabelFile(env))
+	os.RemoveAll(provider.OutputsDir(env))
 	return nil
 }
 
 // ForceCleanup immediately performs cleanup without waiting for TTL
-func ForceCleanup(cfg *config.Config) error {
-	if cfg.Status != "active" {
-		return fmt.Errorf("cluster is not active")
-	}
-
-	return performCleanup(cfg)
+func ForceCleanup(env *config.Environment) error {
+	return performCleanup(env, false)
 }
\ No newline at end of file
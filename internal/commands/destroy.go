@@ -1,9 +1,11 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/killallgit/dick/internal/cleanup"
+	"github.com/killallgit/dick/internal/common"
 	"github.com/killallgit/dick/internal/config"
 	"github.com/killallgit/dick/internal/tui"
 )
@@ -11,63 +13,98 @@ import (
 // DestroyOptions holds configuration for the destroy command
 type DestroyOptions struct {
 	Config *config.Config
+	Name   string
 	Force  bool
+	Output string
 }
 
 // RunDestroy executes the destroy command with the given options
 func RunDestroy(opts DestroyOptions) error {
 	cfg := opts.Config
 
+	doc, err := newDocumentWriter(opts.Output)
+	if err != nil {
+		return err
+	}
+	defer doc.Close()
+
+	env, err := cfg.ResolveEnvironment(opts.Name)
+	if errors.Is(err, config.ErrNoEnvironments) {
+		fmt.Printf("%s No environments to destroy\n", tui.Icon("warning"))
+		if doc.Structured() {
+			return doc.Write()
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
 	// Check for expired clusters before manual destroy
-	// This handles automatic cleanup of expired clusters
-	if err := cleanup.CheckExpirationForCommand(cfg); err != nil {
-		// Don't fail on expiration check errors, just warn
-		fmt.Printf("Warning: expiration check failed: %v\n", err)
+	// This handles automatic cleanup of expired clusters. Forced destroys
+	// (as run by scheduled cleanup jobs) must never prompt, so they skip it.
+	if !opts.Force {
+		if err := recoverInterrupted(cfg); err != nil {
+			fmt.Printf("Warning: recovering interrupted operations failed: %v\n", err)
+		}
+		if err := cleanup.CheckExpirationForCommand(cfg); err != nil {
+			// Don't fail on expiration check errors, just warn
+			fmt.Printf("Warning: expiration check failed: %v\n", err)
+		}
 	}
 
 	// Check if cluster is active (might have been cleaned up by expiration check)
-	if cfg.Status != "active" {
+	if !env.HoldsResources() {
 		fmt.Printf("%s Cluster %s is not active (status: %s)\n", 
 			tui.Icon("warning"),
-			tui.InfoValueStyle.Render(cfg.Name), 
-			tui.FormatStatus(cfg.Status))
+			tui.InfoValueStyle.Render(env.Name), 
+			tui.FormatStatus(env.Status))
+		if doc.Structured() {
+			return doc.Write(env)
+		}
 		return nil
 	}
 
 	// Show confirmation unless --force is used
 	if !opts.Force {
-		confirmed, err := tui.RunConfirmation(
+		confirmed, err := tui.Confirm(
 			"Destroy Cluster",
-			fmt.Sprintf("Are you sure you want to destroy cluster '%s'?\n\nThis action cannot be undone.", cfg.Name),
+			fmt.Sprintf("Are you sure you want to destroy cluster '%s'?\n\nThis action cannot be undone.", env.Name),
+			cfg.Prompts.Destroy,
 		)
 		if err != nil {
 			return fmt.Errorf("failed to show confirmation: %w", err)
 		}
 		
+		// Nobody was asked, so don't let the refusal pass for success
+		if !confirmed && !common.IsInteractive() {
+			return fmt.Errorf("refusing to destroy cluster '%s' without confirmation (pass --yes or --force)", env.Name)
+		}
+		
 		if !confirmed {
 			fmt.Printf("%s Destroy cancelled\n", tui.Icon("warning"))
+			if doc.Structured() {
+				return doc.Write(env)
+			}
 			return nil
 		}
 	}
 
 	fmt.Printf("%s Destroying cluster %s...\n", 
 		tui.Icon("destroy"), 
-		tui.InfoValueStyle.Render(cfg.Name))
-
-	// Cancel any scheduled cleanup first
-	if err := cleanup.CancelScheduledCleanup(cfg); err != nil {
-		fmt.Printf("%s Warning: failed to cancel scheduled cleanup: %v\n", 
-			tui.Icon("warning"), err)
-	}
+		tui.InfoValueStyle.Render(env.Name))
 
-	// Force cleanup immediately
-	if err := cleanup.ForceCleanup(cfg); err != nil {
+	// Force cleanup immediately, which cancels any scheduled cleanup
+	if err := cleanup.ForceCleanup(env); err != nil {
 		return fmt.Errorf("failed to destroy cluster: %w", err)
 	}
 
 	fmt.Printf("%s %s\n", 
 		tui.Icon("success"), 
-		tui.SuccessStyle.Render(fmt.Sprintf("Cluster '%s' destroyed successfully!", cfg.Name)))
+		tui.SuccessStyle.Render(fmt.Sprintf("Cluster '%s' destroyed successfully!", env.Name)))
+	if doc.Structured() {
+		return doc.Write(env)
+	}
 	return nil
 }
 
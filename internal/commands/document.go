@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"os"
+
+	"github.com/killallgit/dick/internal/config"
+	"github.com/killallgit/dick/internal/output"
+)
+
+// documentWriter reserves stdout for the document of a structured --output
+// format. Until the document is written, everything the command prints,
+// including provider output and prompts, goes to stderr instead.
+type documentWriter struct {
+	format  output.Format
+	restore func()
+}
+
+// newDocumentWriter parses an --output value and, for structured formats,
+// redirects stdout to stderr. Callers must Close it.
+func newDocumentWriter(format string) (*documentWriter, error) {
+	f, err := output.ParseFormat(format)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &documentWriter{format: f}
+	if f.Structured() {
+		d.restore = redirectStdout()
+	}
+	return d, nil
+}
+
+// Structured reports whether a document is written instead of text
+func (d *documentWriter) Structured() bool {
+	return d.format.Structured()
+}
+
+// Write restores stdout and writes the document for envs to it
+func (d *documentWriter) Write(envs ...*config.Environment) error {
+	d.Close()
+	return output.Write(os.Stdout, d.format, envs)
+}
+
+// Close restores stdout
+func (d *documentWriter) Close() {
+	if d.restore != nil {
+		d.restore()
+		d.restore = nil
+	}
+}
+
+// redirectStdout sends everything printed to stdout to stderr until the
+// returned function is called. Child processes started in between inherit
+// stderr too.
+func redirectStdout() func() {
+	stdout := os.Stdout
+	os.Stdout = os.Stderr
+	return func() {
+		os.Stdout = stdout
+	}
+}
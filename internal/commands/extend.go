@@ -0,0 +1,167 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/killallgit/dick/internal/cleanup"
+	"github.com/killallgit/dick/internal/config"
+	"github.com/killallgit/dick/internal/tui"
+)
+
+// ExtendOptions holds configuration for the extend command
+type ExtendOptions struct {
+	Config *config.Config
+	Name   string
+	By     string
+	Until  string
+	Output string
+}
+
+// RunExtend pushes out the expiry of an active environment
+func RunExtend(opts ExtendOptions) error {
+	cfg := opts.Config
+
+	doc, err := newDocumentWriter(opts.Output)
+	if err != nil {
+		return err
+	}
+	defer doc.Close()
+
+	env, err := cfg.ResolveEnvironment(opts.Name)
+	if errors.Is(err, config.ErrNoEnvironments) {
+		fmt.Printf("%s No environments to extend\n", tui.Icon("warning"))
+		if doc.Structured() {
+			return doc.Write()
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// Hold the environment lock so nothing else changes or destroys it
+	// mid-extension; the reaper re-checks the expiry once it holds the lock
+	lock, err := config.LockEnvironment(env.ProjectPath, env.Name)
+	if errors.Is(err, config.ErrLocked) {
+		status := env.Status
+		if latest, err := config.ReadEnvironment(env.ProjectPath, env.Name); err == nil && latest != nil {
+			status = latest.Status
+		}
+		return fmt.Errorf("cluster %s is locked by another dick operation (status: %s), cannot extend it",
+			env.Name, status)
+	}
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	// Work on the latest record, another process may have changed it
+	if latest, err := config.ReadEnvironment(env.ProjectPath, env.Name); err != nil {
+		return err
+	} else if latest != nil {
+		env = latest
+	}
+
+	if !env.IsActive() {
+		fmt.Printf("%s Cluster %s is not active (status: %s)\n",
+			tui.Icon("warning"),
+			tui.InfoValueStyle.Render(env.Name),
+			tui.FormatStatus(env.Status))
+		if doc.Structured() {
+			return doc.Write(env)
+		}
+		return nil
+	}
+
+	expiresAt, err := extensionTarget(env, opts.By, opts.Until, time.Now())
+	if err != nil {
+		return err
+	}
+
+	previous := env.ExpiresAt
+	if err := env.Update(func(current *config.Environment) error {
+		return current.Extend(expiresAt)
+	}); err != nil {
+		return err
+	}
+
+	// Move the OS-level cleanup job to the new expiry
+	if err := cleanup.RescheduleCleanup(cfg, env); err != nil {
+		fmt.Printf("%s Failed to reschedule cleanup job: %v\n", tui.Icon("warning"), err)
+	}
+
+	// The reaper may have exited if the environment was overdue
+	if err := cleanup.EnsureReaper(); err != nil {
+		fmt.Printf("%s Failed to start the reaper: %v\n", tui.Icon("warning"), err)
+	}
+
+	fmt.Printf("%s %s\n",
+		tui.Icon("success"),
+		tui.SuccessStyle.Render(fmt.Sprintf("Cluster '%s' extended by %s", env.Name,
+			env.ExpiresAt.Sub(previous).Round(time.Second))))
+	fmt.Printf("%-15s %s\n",
+		tui.InfoLabelStyle.Render("EXPIRES AT:"),
+		tui.WarningStyle.Render(env.ExpiresAt.Format("2006-01-02 15:04:05")))
+	fmt.Printf("%-15s %s\n",
+		tui.InfoLabelStyle.Render("REMAINING:"),
+		tui.InfoValueStyle.Render(env.TimeRemaining().Round(time.Second).String()))
+
+	if doc.Structured() {
+		return doc.Write(env)
+	}
+	return nil
+}
+
+// extensionTarget computes the new expiry from --until, or from --by which
+// defaults to the environment's TTL. Extensions of an overdue environment
+// count from now.
+func extensionTarget(env *config.Environment, by, until string, now time.Time) (time.Time, error) {
+	if until != "" {
+		return parseUntil(until, now)
+	}
+
+	if by == "" {
+		by = env.TTL
+	}
+	duration, err := time.ParseDuration(by)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid duration '%s': %w (examples: 30m, 1h)", by, err)
+	}
+	if duration <= 0 {
+		return time.Time{}, fmt.Errorf("extension must be positive: %s", by)
+	}
+
+	base := env.ExpiresAt
+	if base.Before(now) {
+		base = now
+	}
+	return base.Add(duration), nil
+}
+
+// parseUntil parses an absolute expiry. A time of day without a date refers
+// to its next occurrence.
+func parseUntil(value string, now time.Time) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02 15:04"} {
+		if t, err := time.ParseInLocation(layout, value, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+
+	for _, layout := range []string{"15:04", "15:04:05", "3:04pm", "3pm"} {
+		clock, err := time.ParseInLocation(layout, value, now.Location())
+		if err != nil {
+			continue
+		}
+
+		t := time.Date(now.Year(), now.Month(), now.Day(),
+			clock.Hour(), clock.Minute(), clock.Second(), 0, now.Location())
+		if !t.After(now) {
+			t = t.AddDate(0, 0, 1)
+		}
+		return t, nil
+	}
+
+	return time.Time{}, fmt.Errorf("invalid time '%s' (examples: 18:00, 18:30:00, 6pm, 2025-01-02 18:00)", value)
+}
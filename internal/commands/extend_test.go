@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/killallgit/dick/internal/config"
+)
+
+func TestExtensionTarget(t *testing.T) {
+	now := time.Date(2025, 1, 2, 15, 0, 0, 0, time.Local)
+	expiresAt := now.Add(10 * time.Minute)
+	env := &config.Environment{Name: "dev", TTL: "30m", Status: "active", ExpiresAt: expiresAt}
+	overdue := &config.Environment{Name: "dev", TTL: "30m", Status: "active", ExpiresAt: now.Add(-time.Hour)}
+
+	tests := []struct {
+		name    string
+		env     *config.Environment
+		by      string
+		until   string
+		want    time.Time
+		wantErr bool
+	}{
+		{name: "defaults to the TTL", env: env, want: expiresAt.Add(30 * time.Minute)},
+		{name: "by", env: env, by: "1h", want: expiresAt.Add(time.Hour)},
+		{name: "overdue counts from now", env: overdue, by: "1h", want: now.Add(time.Hour)},
+		{name: "overdue defaults to the TTL", env: overdue, want: now.Add(30 * time.Minute)},
+		{name: "until time of day later today", env: env, until: "18:00", want: time.Date(2025, 1, 2, 18, 0, 0, 0, time.Local)},
+		{name: "until time of day already past", env: env, until: "9am", want: time.Date(2025, 1, 3, 9, 0, 0, 0, time.Local)},
+		{name: "until now is tomorrow", env: env, until: "15:00", want: time.Date(2025, 1, 3, 15, 0, 0, 0, time.Local)},
+		{name: "until date and time", env: env, until: "2025-01-05 08:30", want: time.Date(2025, 1, 5, 8, 30, 0, 0, time.Local)},
+		{name: "until RFC 3339", env: env, until: "2025-01-05T08:30:00Z", want: time.Date(2025, 1, 5, 8, 30, 0, 0, time.UTC)},
+		{name: "until wins over by", env: env, by: "1h", until: "18:00", want: time.Date(2025, 1, 2, 18, 0, 0, 0, time.Local)},
+		{name: "invalid by", env: env, by: "soon", wantErr: true},
+		{name: "zero by", env: env, by: "0s", wantErr: true},
+		{name: "negative by", env: env, by: "-5m", wantErr: true},
+		{name: "invalid until", env: env, until: "tomorrow", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := extensionTarget(tt.env, tt.by, tt.until, now)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("extensionTarget() = %s, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("extensionTarget() failed: %v", err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("extensionTarget() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
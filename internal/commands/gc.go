@@ -0,0 +1,249 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/killallgit/dick/internal/cleanup"
+	"github.com/killallgit/dick/internal/common"
+	"github.com/killallgit/dick/internal/config"
+	"github.com/killallgit/dick/internal/provider"
+	"github.com/killallgit/dick/internal/tui"
+)
+
+// GCOptions holds configuration for the gc command
+type GCOptions struct {
+	Config *config.Config
+	DryRun bool
+	Force  bool
+
+	// All includes resources of other users and unowned resources
+	All bool
+}
+
+// orphan is a resource dick created whose environment no longer owns it
+type orphan struct {
+	resource provider.Resource
+
+	// env is the environment the resource belongs to, nil if none is known
+	env *config.Environment
+
+	// reason says why the resource is an orphan
+	reason string
+}
+
+// RunGC finds the resources dick created whose environment is unknown,
+// destroyed or expired and deletes them
+func RunGC(opts GCOptions) error {
+	cfg := opts.Config
+	ctx := context.Background()
+
+	resources, err := provider.Resources(ctx)
+	if err != nil {
+		// Resources found by the other providers can still be collected
+		fmt.Printf("%s Warning: %v\n", tui.Icon("warning"), err)
+	}
+
+	envs := gcEnvironments(cfg, resources)
+
+	var orphans []orphan
+	skipped := 0
+	for _, r := range resources {
+		env := owningEnvironment(r, envs)
+		reason := orphanReason(env)
+		if reason == "" {
+			continue
+		}
+		if !opts.All && !ownedByCurrentUser(r, env, provider.CurrentOwner()) {
+			skipped++
+			continue
+		}
+		orphans = append(orphans, orphan{resource: r, env: env, reason: reason})
+	}
+	if skipped > 0 {
+		fmt.Printf("%s Skipped %d orphaned resource(s) of other users or without an owner (use --all to include them)\n",
+			tui.Icon("info"), skipped)
+	}
+
+	if len(orphans) == 0 {
+		fmt.Printf("%s No orphaned resources found (%d checked)\n", tui.Icon("success"), len(resources))
+		return nil
+	}
+
+	fmt.Printf("%s Found %d orphaned resource(s):\n", tui.Icon("info"), len(orphans))
+	for _, o := range orphans {
+		fmt.Printf("  • %s %s %s\n",
+			o.resource.Type,
+			tui.InfoValueStyle.Render(o.resource.Name),
+			tui.WarningStyle.Render("("+o.reason+")"))
+	}
+
+	if opts.DryRun {
+		fmt.Printf("%s Dry run, nothing was deleted\n", tui.Icon("info"))
+		return nil
+	}
+
+	if !opts.Force {
+		confirmed, err := tui.Confirm(
+			"Delete Orphaned Resources",
+			fmt.Sprintf("Delete %d orphaned resource(s)?\n\nThis action cannot be undone.", len(orphans)),
+			cfg.Prompts.GC,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to show confirmation: %w", err)
+		}
+		// Nobody was asked, so don't let the refusal pass for success
+		if !confirmed && !common.IsInteractive() {
+			return fmt.Errorf("refusing to delete orphaned resources without confirmation (pass --yes or --force)")
+		}
+		if !confirmed {
+			fmt.Printf("%s GC cancelled\n", tui.Icon("warning"))
+			return nil
+		}
+	}
+
+	var errs []error
+	destroyed := make(map[*config.Environment]bool)
+	for _, o := range orphans {
+		if err := collect(ctx, o, destroyed); err != nil {
+			fmt.Printf("%s Failed to delete %s %s: %v\n", tui.Icon("error"), o.resource.Type, o.resource.Name, err)
+			errs = append(errs, fmt.Errorf("%s: %w", o.resource.Name, err))
+			continue
+		}
+		fmt.Printf("%s Deleted %s %s\n", tui.Icon("success"), o.resource.Type, tui.InfoValueStyle.Render(o.resource.Name))
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to delete %d of %d orphaned resource(s): %w", len(errs), len(orphans), errors.Join(errs...))
+	}
+	return nil
+}
+
+// gcEnvironments returns the environments of the current project, of every
+// known project and of the projects named by resource labels
+func gcEnvironments(cfg *config.Config, resources []provider.Resource) []*config.Environment {
+	envs := cfg.ListEnvironments()
+	seen := map[string]bool{cfg.ProjectPath: true}
+
+	projects, err := config.KnownProjects()
+	if err != nil {
+		fmt.Printf("%s Warning: failed to read project index: %v\n", tui.Icon("warning"), err)
+	}
+	for _, r := range resources {
+		if r.Project != "" {
+			projects = append(projects, r.Project)
+		}
+	}
+
+	for _, projectPath := range projects {
+		if seen[projectPath] {
+			continue
+		}
+		seen[projectPath] = true
+
+		if _, err := os.Stat(projectPath); err != nil {
+			continue
+		}
+		projectCfg, err := config.LoadProjectConfig(projectPath)
+		if err != nil {
+			fmt.Printf("%s Warning: failed to load project %s: %v\n", tui.Icon("warning"), projectPath, err)
+			continue
+		}
+		envs = append(envs, projectCfg.ListEnvironments()...)
+	}
+	return envs
+}
+
+// owningEnvironment returns the environment a resource belongs to, the one
+// holding resources when several records match, nil if none does
+func owningEnvironment(r provider.Resource, envs []*config.Environment) *config.Environment {
+	var owner *config.Environment
+	for _, env := range envs {
+		if !r.BelongsTo(env) {
+			continue
+		}
+		if env.HoldsResources() {
+			return env
+		}
+		owner = env
+	}
+	return owner
+}
+
+// ownedByCurrentUser reports whether gc may collect a resource without --all:
+// its owner label names the user, or it has no owner label, e.g. a kind
+// cluster, but belongs to one of the user's environments
+func ownedByCurrentUser(r provider.Resource, env *config.Environment, user string) bool {
+	if r.Owner != "" {
+		return r.Owner == user
+	}
+	return env != nil
+}
+
+// orphanReason says why a resource of env is an orphan, empty if the
+// environment still owns it
+func orphanReason(env *config.Environment) string {
+	switch {
+	case env == nil:
+		return "no environment"
+	case env.InProgress():
+		return ""
+	case env.IsDrifted():
+		return fmt.Sprintf("environment '%s' drifted", env.Name)
+	case env.HoldsResources() && env.IsExpired():
+		return fmt.Sprintf("environment '%s' expired at %s", env.Name, env.ExpiresAt.Format("2006-01-02 15:04:05"))
+	case env.HoldsResources():
+		return ""
+	default:
+		return fmt.Sprintf("environment '%s' is %s", env.Name, env.Status)
+	}
+}
+
+// collect deletes an orphan. Resources of an environment still holding
+// resources are removed by destroying the environment, once, so its record
+// stays accurate; the others are deleted directly.
+func collect(ctx context.Context, o orphan, destroyed map[*config.Environment]bool) error {
+	if o.env == nil {
+		return o.resource.Delete(ctx)
+	}
+
+	lock, err := config.LockEnvironment(o.env.ProjectPath, o.env.Name)
+	if errors.Is(err, config.ErrLocked) {
+		return fmt.Errorf("environment '%s' is being created or destroyed by another process", o.env.Name)
+	}
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	// The environment may have been recreated or destroyed since it was listed
+	current, err := config.ReadEnvironment(o.env.ProjectPath, o.env.Name)
+	if err != nil {
+		return err
+	}
+	if current == nil || !o.resource.BelongsTo(current) {
+		return o.resource.Delete(ctx)
+	}
+	if orphanReason(current) == "" {
+		return fmt.Errorf("environment '%s' is %s again", current.Name, current.Status)
+	}
+	*o.env = *current
+
+	if o.env.HoldsResources() && !destroyed[o.env] {
+		if err := cleanup.CancelScheduledCleanup(o.env); err != nil {
+			fmt.Printf("%s Warning: failed to cancel scheduled cleanup: %v\n", tui.Icon("warning"), err)
+		}
+		if err := cleanup.DestroyLocked(o.env); err != nil {
+			return err
+		}
+		destroyed[o.env] = true
+	}
+
+	// Destroying the environment removes what its provider created
+	if destroyed[o.env] && o.resource.Provider == o.env.Provider {
+		return nil
+	}
+	return o.resource.Delete(ctx)
+}
@@ -0,0 +1,35 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/killallgit/dick/internal/config"
+	"github.com/killallgit/dick/internal/provider"
+)
+
+func TestOwnedByCurrentUser(t *testing.T) {
+	env := &config.Environment{Name: "dev", ID: "0123456789ab", Status: "destroyed"}
+
+	tests := []struct {
+		name     string
+		resource provider.Resource
+		env      *config.Environment
+		want     bool
+	}{
+		{"own container", provider.Resource{Type: "docker container", Owner: "alice"}, nil, true},
+		{"own container of a known environment", provider.Resource{Type: "docker container", Owner: "alice"}, env, true},
+		{"foreign container", provider.Resource{Type: "docker container", Owner: "bob"}, nil, false},
+		{"foreign container matching a known environment", provider.Resource{Type: "docker container", Owner: "bob"}, env, false},
+		{"kind cluster of a known environment", provider.Resource{Type: "kind cluster"}, env, true},
+		{"kind cluster of no known environment", provider.Resource{Type: "kind cluster"}, nil, false},
+		{"unowned container", provider.Resource{Type: "docker container"}, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ownedByCurrentUser(tt.resource, tt.env, "alice"); got != tt.want {
+				t.Errorf("ownedByCurrentUser() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/killallgit/dick/internal/config"
+	"github.com/killallgit/dick/internal/provider"
+	"github.com/killallgit/dick/internal/templates"
+	"github.com/killallgit/dick/internal/tui"
+)
+
+// HooksOptions holds configuration for the hooks commands
+type HooksOptions struct {
+	Config   *config.Config
+	Provider string
+	Force    bool
+}
+
+// RunHooksShow prints the hook files a provider runs for the project, its
+// own or the built-in ones, each preceded by a comment naming it
+func RunHooksShow(opts HooksOptions) error {
+	p, err := provider.Get(opts.Provider)
+	if err != nil {
+		return err
+	}
+
+	source, ok := p.(provider.HookSource)
+	if !ok {
+		fmt.Printf("%s Provider %s runs no hook files\n", tui.Icon("info"), p.Name())
+		return nil
+	}
+
+	// Providers read their settings from the configuration an environment
+	// would be created with
+	env := opts.Config.NewEnvironment()
+	env.Provider = p.Name()
+	files, builtin, err := source.HookFiles(env)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		fmt.Printf("%s Provider %s runs no hook files with the settings of %s\n",
+			tui.Icon("info"), p.Name(), config.ConfigFileName)
+		return nil
+	}
+
+	origin := "project"
+	if builtin {
+		origin = "built-in, run 'dick hooks eject' to customize"
+	}
+	for i, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to read hook file: %w", err)
+		}
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("# %s (%s)\n%s", projectRelative(opts.Config.ProjectPath, file), origin, data)
+	}
+	return nil
+}
+
+// RunHooksEject writes the built-in hook files of a provider into the
+// project, so they can be customized. Existing files are kept unless Force
+// is set.
+func RunHooksEject(opts HooksOptions) error {
+	p, err := provider.Get(opts.Provider)
+	if err != nil {
+		return err
+	}
+
+	builtins, err := templates.ForProvider(p.Name())
+	if err != nil {
+		return err
+	}
+	if len(builtins) == 0 {
+		fmt.Printf("%s Provider %s has no built-in hook files\n", tui.Icon("info"), p.Name())
+		return nil
+	}
+
+	projectDir := opts.Config.ProjectPath
+	written, err := templates.Scaffold(projectDir, p.Name(), opts.Force)
+	for _, path := range written {
+		fmt.Printf("%s Wrote %s\n", tui.Icon("success"), tui.InfoValueStyle.Render(projectRelative(projectDir, path)))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to eject hook files: %w", err)
+	}
+
+	if len(written) < len(builtins) {
+		fmt.Printf("%s Kept %d existing file(s), pass --force to overwrite them\n",
+			tui.Icon("warning"), len(builtins)-len(written))
+	}
+	if p.Name() == "kind" && !opts.Config.Kind.Taskfile {
+		fmt.Printf("%s Set kind.taskfile to true in %s to run the Taskfile instead of kind\n",
+			tui.Icon("info"), config.ConfigFileName)
+	}
+	return nil
+}
+
+// projectRelative returns a path relative to the project, or as is when it
+// lies outside
+func projectRelative(projectDir, path string) string {
+	rel, err := filepath.Rel(projectDir, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return path
+	}
+	return rel
+}
@@ -0,0 +1,147 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/killallgit/dick/internal/common"
+	"github.com/killallgit/dick/internal/config"
+	"github.com/killallgit/dick/internal/provider"
+	"github.com/killallgit/dick/internal/templates"
+	"github.com/killallgit/dick/internal/tui"
+)
+
+// InitOptions holds configuration for the init command
+type InitOptions struct {
+	Defaults bool
+	Force    bool
+}
+
+// RunInit writes a .dick.yaml for the current directory, asking for the
+// settings unless defaults are requested, and scaffolds the provider's hook files
+func RunInit(opts InitOptions) error {
+	projectDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	configPath := filepath.Join(projectDir, config.ConfigFileName)
+	if _, err := os.Stat(configPath); err == nil && !opts.Force {
+		return fmt.Errorf("%s already exists (use --force to overwrite)", configPath)
+	}
+
+	settings := config.ProjectSettings{
+		Provider: "kind",
+		TTL:      "5m",
+		Name:     "dev-cluster",
+	}
+	scaffold := false
+
+	if !opts.Defaults {
+		if !common.IsInteractive() {
+			return fmt.Errorf("the wizard needs an interactive terminal; use --defaults to initialize without it")
+		}
+
+		answers, err := tui.RunWizard("Initialize dick project", initWizardFields(settings))
+		if errors.Is(err, tui.ErrWizardCancelled) {
+			fmt.Printf("%s Init cancelled\n", tui.Icon("warning"))
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to run wizard: %w", err)
+		}
+
+		settings.Provider = answers["provider"]
+		settings.TTL = answers["ttl"]
+		settings.Name = answers["name"]
+		settings.AutoDestroy = answers["policy"] == "auto"
+		scaffold = answers["scaffold"] == "yes"
+		// Scaffolded kind hooks are only used in Taskfile mode
+		settings.KindTaskfile = settings.Provider == "kind" && scaffold
+	}
+
+	if _, err := config.WriteProjectConfig(projectDir, settings, opts.Force); err != nil {
+		return err
+	}
+	fmt.Printf("%s Wrote %s\n", tui.Icon("success"), tui.InfoValueStyle.Render(config.ConfigFileName))
+
+	if scaffold {
+		written, err := templates.Scaffold(projectDir, settings.Provider, opts.Force)
+		if err != nil {
+			return fmt.Errorf("failed to scaffold hook files: %w", err)
+		}
+		for _, path := range written {
+			if rel, err := filepath.Rel(projectDir, path); err == nil {
+				path = rel
+			}
+			fmt.Printf("%s Wrote %s\n", tui.Icon("success"), tui.InfoValueStyle.Render(path))
+		}
+	}
+
+	fmt.Printf("%s Run 'dick new' to create your first environment\n", tui.Icon("info"))
+	return nil
+}
+
+// initWizardFields returns the questions asked by 'dick init', defaulting to settings
+func initWizardFields(settings config.ProjectSettings) []*tui.WizardField {
+	var providers []tui.WizardOption
+	for _, p := range provider.All() {
+		providers = append(providers, tui.WizardOption{Value: p.Name(), Description: p.Description()})
+	}
+
+	required := func(validate func(string) error) func(string) error {
+		return func(value string) error {
+			if strings.TrimSpace(value) == "" {
+				return fmt.Errorf("a value is required")
+			}
+			return validate(value)
+		}
+	}
+
+	return []*tui.WizardField{
+		{
+			Key:     "provider",
+			Title:   "Provider",
+			Help:    "Infrastructure the environments are created with",
+			Options: providers,
+			Value:   settings.Provider,
+		},
+		{
+			Key:      "ttl",
+			Title:    "Default TTL",
+			Help:     "How long environments live before they are destroyed (e.g. 5m, 1h)",
+			Value:    settings.TTL,
+			Validate: required(config.ValidateTTL),
+		},
+		{
+			Key:      "name",
+			Title:    "Default name",
+			Help:     "Name of the environment when --name isn't given",
+			Value:    settings.Name,
+			Validate: required(config.ValidateName),
+		},
+		{
+			Key:   "policy",
+			Title: "Expired environments",
+			Help:  "What to do with expired environments found when running a command",
+			Options: []tui.WizardOption{
+				{Value: "prompt", Description: "ask before destroying them"},
+				{Value: "auto", Description: "destroy them without asking"},
+			},
+			Value: "prompt",
+		},
+		{
+			Key:   "scaffold",
+			Title: "Scaffold hook files",
+			Help:  "Write starter hook files for the provider into the project",
+			Options: []tui.WizardOption{
+				{Value: "yes", Description: "write the provider's hook files"},
+				{Value: "no", Description: "keep the project as it is"},
+			},
+			Value: "yes",
+		},
+	}
+}
@@ -1,11 +1,13 @@
 package commands
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
-	"os/exec"
 	"os/signal"
 	"path/filepath"
+	"sort"
 	"strings"
 	"syscall"
 	"time"
@@ -13,6 +15,7 @@ import (
 	"github.com/killallgit/dick/internal/cleanup"
 	"github.com/killallgit/dick/internal/common"
 	"github.com/killallgit/dick/internal/config"
+	"github.com/killallgit/dick/internal/provider"
 	"github.com/killallgit/dick/internal/tui"
 )
 
@@ -23,46 +26,146 @@ type NewOptions struct {
 	Name     string
 	Wait     bool
 	Force    bool
+	Output   string
+
+	// Set and Values set the parameters declared in .dick.yaml
+	Set    []string
+	Values []string
 }
 
 // RunNew executes the new command with the given options
 func RunNew(opts NewOptions) error {
-	var cfg *config.Config
-	var err error
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	doc, err := newDocumentWriter(opts.Output)
+	if err != nil {
+		return err
+	}
+	defer doc.Close()
+
+	env, err := createEnvironment(cfg, opts)
+	if err != nil {
+		return err
+	}
+
+	// Start Go-based TTL timer (no system scheduling)
+	if err := cleanup.StartTTLTimer(cfg, env); err != nil {
+		return fmt.Errorf("failed to start TTL timer: %w", err)
+	}
+
+	// Scripts get the record instead of the dashboard; the reaper enforces the TTL
+	if doc.Structured() {
+		return doc.Write(env)
+	}
+
+	// Detached, or without a terminal for the dashboard, the reaper takes over
+	if !opts.Wait || !common.IsInteractive() {
+		reportDetached(env)
+		return nil
+	}
+
+	// Always run in watch mode (no conditional check)
+	fmt.Printf("\n%s Starting real-time dashboard - process will remain active until cleanup\n", 
+		tui.Icon("timer"))
+	fmt.Printf("%s Press Ctrl+C to exit early (the reaper destroys the cluster when its TTL expires)\n", 
+		tui.Icon("info"))
+	
+	// Start watch mode using the existing TUI
+	if err := startWatchMode(cfg, env); err != nil {
+		return fmt.Errorf("error in watch mode: %w", err)
+	}
+
+	return nil
+}
+
+// reportDetached tells how the TTL of an environment is enforced once dick exits
+func reportDetached(env *config.Environment) {
+	enforcers := []string{}
+	if status, err := cleanup.GetReaperStatus(); err == nil && status.Running {
+		enforcers = append(enforcers, fmt.Sprintf("the reaper (pid %d)", status.PID))
+	}
+	if env.ScheduledJobID != "" {
+		enforcers = append(enforcers, fmt.Sprintf("%s job %s", env.Scheduler, env.ScheduledJobID))
+	}
+
+	if len(enforcers) == 0 {
+		fmt.Printf("\n%s Nothing will destroy cluster %s when its TTL expires; run 'dick destroy --name %s' when done\n",
+			tui.Icon("warning"), tui.InfoValueStyle.Render(env.Name), env.Name)
+		return
+	}
+	fmt.Printf("\n%s Detached - %s will destroy the cluster at %s\n",
+		tui.Icon("timer"),
+		strings.Join(enforcers, " and "),
+		tui.WarningStyle.Render(env.ExpiresAt.Format("15:04:05")))
+}
+
+// createEnvironment creates the environment described by the config and
+// options, makes it active and hands its TTL to the reaper and the cleanup
+// job. Callers that stay around until the expiry may start a TTL timer too.
+func createEnvironment(cfg *config.Config, opts NewOptions) (*config.Environment, error) {
+	if !config.ConfigFileFound() {
+		fmt.Printf("%s No .dick.yaml found, using defaults (run 'dick init' to create one)\n", tui.Icon("info"))
+	}
 
 	if opts.Force {
-		// Create new config with defaults when force flag is used
-		cfg = &config.Config{
-			Provider: "kind",
-			TTL:      "5m",
-			Name:     "dev-cluster",
-		}
+		// Reset settings to defaults when force flag is used; the
+		// environment registry is kept so other environments aren't orphaned
+		cfg.Provider = "kind"
+		cfg.TTL = "5m"
+		cfg.Name = "dev-cluster"
 		fmt.Printf("%s Force flag enabled - creating new configuration\n", tui.Icon("warning"))
-	} else {
-		// Load existing config
-		cfg, err = config.LoadConfig()
-		if err != nil {
-			return fmt.Errorf("failed to load config: %w", err)
-		}
+	}
+
+	// Apply CLI flag overrides
+	applyFlags(cfg, opts)
+
+	// Bad parameters fail before anything is touched
+	params, err := config.ResolveParams(cfg.Params, opts.Values, opts.Set)
+	if err != nil {
+		return nil, err
 	}
 
 	// Check for expired clusters before creating new ones
 	// This ensures any existing expired clusters are cleaned up first
+	// Resume or roll back operations of a dick that was killed
+	if err := recoverInterrupted(cfg); err != nil {
+		fmt.Printf("%s Warning: recovering interrupted operations failed: %v\n", tui.Icon("warning"), err)
+	}
+
+	previous := cfg.Environment(cfg.Name)
+	wasActive := previous != nil && previous.IsActive()
 	if err := cleanup.CheckExpirationForCommand(cfg); err != nil {
 		// Don't fail on expiration check errors, just warn
 		fmt.Printf("%s Warning: expiration check failed: %v\n", tui.Icon("warning"), err)
-	} else if cfg.Status == "destroyed" {
+	} else if wasActive && !previous.IsActive() {
 		// Expired cluster was found and cleaned up
 		fmt.Printf("%s Cleaned up expired cluster before creating new one\n", tui.Icon("success"))
 	}
 
-	// Apply CLI flag overrides
-	applyFlags(cfg, opts)
+	// Refuse to overwrite the record of an environment that is still running
+	if previous != nil && previous.HoldsResources() {
+		return nil, fmt.Errorf("environment '%s' is already %s (expires %s); destroy it first or choose another --name",
+			previous.Name, previous.Status, previous.ExpiresAt.Format("15:04:05"))
+	}
 
 	// Validate TTL format
 	duration, err := cfg.ParseTTL()
 	if err != nil {
-		return fmt.Errorf("invalid TTL: %w", err)
+		return nil, fmt.Errorf("invalid TTL: %w", err)
+	}
+
+	env := cfg.NewEnvironment()
+	env.Params = params
+
+	p, err := provider.Get(env.Provider)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.Validate(env); err != nil {
+		return nil, fmt.Errorf("provider %s: %w", p.Name(), err)
 	}
 
 	// Display fancy header with colored row
@@ -70,44 +173,71 @@ func RunNew(opts NewOptions) error {
 	fmt.Printf("%s %s %s %s %s %s %s\n",
 		tui.HeaderStyle.Render("CREATING"),
 		tui.InfoLabelStyle.Render("Provider:"),
-		tui.SuccessStyle.Render(cfg.Provider),
+		tui.SuccessStyle.Render(env.Provider),
 		tui.InfoLabelStyle.Render("Name:"),
-		tui.InfoValueStyle.Render(cfg.Name),
+		tui.InfoValueStyle.Render(env.Name),
 		tui.InfoLabelStyle.Render("TTL:"),
 		tui.WarningStyle.Render(duration.String()))
+	if len(params) > 0 {
+		fmt.Printf("%s %s\n", tui.InfoLabelStyle.Render("Params:"), tui.InfoValueStyle.Render(formatParams(params)))
+	}
 	fmt.Printf("%s\n\n", tui.Divider(60))
 
-	// Execute the create task
-	if err := executeCreateTask(cfg); err != nil {
-		return fmt.Errorf("failed to create cluster: %w", err)
+	// Own the environment while it is created, so other commands can tell
+	// a running 'dick new' from one that was interrupted
+	lock, err := config.LockEnvironment(env.ProjectPath, env.Name)
+	if errors.Is(err, config.ErrLocked) {
+		return nil, fmt.Errorf("environment '%s' is being created or destroyed by another process", env.Name)
 	}
-
-	// Mark cluster as active and save state
-	if err := cfg.SetActive(); err != nil {
-		return fmt.Errorf("failed to set cluster active: %w", err)
+	if err != nil {
+		return nil, err
 	}
+	defer lock.Unlock()
 
-	if err := config.SaveConfig(cfg); err != nil {
-		return fmt.Errorf("failed to save config: %w", err)
+	// A failed create rolls back what it made, so it must not start on
+	// infrastructure that already exists and belongs to someone else
+	state, err := p.Status(context.Background(), env)
+	if err != nil {
+		fmt.Printf("%s Warning: failed to check for existing infrastructure: %v\n", tui.Icon("warning"), err)
+	}
+	if state == provider.StateRunning {
+		return nil, fmt.Errorf("%s infrastructure for environment '%s' already exists; delete it or choose another --name",
+			p.Name(), env.Name)
 	}
 
-	// Save config again after scheduling to persist job ID
-	defer func() {
-		if err := config.SaveConfig(cfg); err != nil {
-			fmt.Printf("%s Warning: failed to save config: %v\n", tui.Icon("warning"), err)
+	// Record the environment before creating it, so an interrupted create
+	// is found later and the TTL applies even if it never becomes ready
+	if err := env.SetProvisioning(); err != nil {
+		return nil, fmt.Errorf("failed to set cluster provisioning: %w", err)
+	}
+	if err := env.Update(func(current *config.Environment) error {
+		// Another 'dick new' may have created it since it was checked above
+		if current.HoldsResources() {
+			return fmt.Errorf("environment '%s' is already %s; destroy it first or choose another --name",
+				current.Name, current.Status)
 		}
-	}()
+		*current = *env
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	cfg.PutEnvironment(env)
 
-	// Start Go-based TTL timer (no system scheduling)
-	if err := cleanup.StartTTLTimer(cfg); err != nil {
-		return fmt.Errorf("failed to start TTL timer: %w", err)
+	if err := provisionEnvironment(cfg, p, env, true); err != nil {
+		return nil, err
+	}
+
+	// The reaper enforces the TTL once this process has exited
+	if err := cleanup.EnsureReaper(); err != nil {
+		fmt.Printf("%s Failed to start the reaper, the cluster will not be destroyed after exit: %v\n",
+			tui.Icon("warning"), err)
 	}
 
 	// Display success with fancy formatting
 	fmt.Printf("\n%s\n", tui.Divider(60))
 	fmt.Printf("%s %s\n", 
 		tui.SuccessStyle.Render("✓"),
-		tui.SuccessStyle.Render(fmt.Sprintf("Cluster '%s' created successfully!", cfg.Name)))
+		tui.SuccessStyle.Render(fmt.Sprintf("Cluster '%s' created successfully!", env.Name)))
 	fmt.Printf("%s\n", tui.Divider(60))
 	
 	// Display cluster details in a formatted table
@@ -116,32 +246,34 @@ func RunNew(opts NewOptions) error {
 		tui.StatusActiveStyle.Render("ACTIVE"))
 	fmt.Printf("%-15s %s\n", 
 		tui.InfoLabelStyle.Render("PROVIDER:"), 
-		tui.InfoValueStyle.Render(cfg.Provider))
+		tui.InfoValueStyle.Render(env.Provider))
 	fmt.Printf("%-15s %s\n", 
 		tui.InfoLabelStyle.Render("NAME:"), 
-		tui.InfoValueStyle.Render(cfg.Name))
+		tui.InfoValueStyle.Render(env.Name))
 	fmt.Printf("%-15s %s\n", 
 		tui.InfoLabelStyle.Render("TTL:"), 
 		tui.WarningStyle.Render(duration.String()))
 	fmt.Printf("%-15s %s\n", 
 		tui.InfoLabelStyle.Render("EXPIRES AT:"), 
-		tui.WarningStyle.Render(cfg.ExpiresAt.Format("15:04:05")))
+		tui.WarningStyle.Render(env.ExpiresAt.Format("15:04:05")))
 	fmt.Printf("%-15s %s\n", 
 		tui.InfoLabelStyle.Render("PROJECT PATH:"), 
-		tui.InfoValueStyle.Render(cfg.ProjectPath))
-
-	// Always run in watch mode (no conditional check)
-	fmt.Printf("\n%s Starting real-time dashboard - process will remain active until cleanup\n", 
-		tui.Icon("timer"))
-	fmt.Printf("%s Press Ctrl+C to exit early (TTL timer will continue in background)\n", 
-		tui.Icon("info"))
-	
-	// Start watch mode using the existing TUI
-	if err := startWatchMode(cfg); err != nil {
-		return fmt.Errorf("error in watch mode: %w", err)
+		tui.InfoValueStyle.Render(env.ProjectPath))
+	if env.ScheduledJobID != "" {
+		fmt.Printf("%-15s %s\n", 
+			tui.InfoLabelStyle.Render("CLEANUP JOB:"), 
+			tui.InfoValueStyle.Render(env.Scheduler+" "+env.ScheduledJobID))
+	}
+	if len(env.Outputs) > 0 {
+		fmt.Printf("\n%s\n", tui.InfoLabelStyle.Render("OUTPUTS:"))
+		for _, key := range sortedKeys(env.Outputs) {
+			fmt.Printf("  %-13s %s\n", 
+				tui.InfoLabelStyle.Render(key+":"), 
+				tui.InfoValueStyle.Render(env.Outputs[key]))
+		}
 	}
 
-	return nil
+	return env, nil
 }
 
 // applyFlags applies command line flag overrides to the config
@@ -157,56 +289,32 @@ func applyFlags(cfg *config.Config, opts NewOptions) {
 	}
 }
 
-// executeCreateTask runs the appropriate create task based on provider
-func executeCreateTask(cfg *config.Config) error {
-	// Check if task command is available
-	if _, err := exec.LookPath("task"); err != nil {
-		return fmt.Errorf("task command not found. Please install Task: https://taskfile.dev/installation/")
-	}
+// executeCreateTask creates the environment with its provider, showing a
+// spinner unless task output is shown
+func executeCreateTask(p provider.Provider, env *config.Environment) error {
+	// Interrupting the create makes the provider stop its tools, so the
+	// partial environment can be rolled back
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	// Get current working directory
-	pwd, err := os.Getwd()
-	if err != nil {
-		return fmt.Errorf("failed to get current directory: %w", err)
+	// Keep the tool output for debugging a failed create
+	if logFile, err := openCreateLog(env); err != nil {
+		fmt.Printf("%s Failed to open create log: %v\n", tui.Icon("warning"), err)
+	} else {
+		defer logFile.Close()
+		ctx = provider.WithOutputLog(ctx, logFile)
 	}
 
-	// Construct task file path based on provider (noun-based)
-	var taskFile string
-	switch cfg.Provider {
-	case "kind":
-		taskFile = filepath.Join(pwd, "tasks", "Taskfile.k8s.yaml")
-	default:
-		return fmt.Errorf("unsupported provider: %s", cfg.Provider)
-	}
-	
-	// Check if new taskfile exists, fallback to legacy if needed
-	if _, err := os.Stat(taskFile); err != nil {
-		// Fallback to legacy taskfile for backward compatibility
-		taskFile = filepath.Join(pwd, "tasks", "Taskfile.new.yaml")
-		if _, err := os.Stat(taskFile); err != nil {
-			return fmt.Errorf("no taskfile found for provider %s: tried %s and %s", 
-				cfg.Provider, taskFile, filepath.Join(pwd, "tasks", "Taskfile.k8s.yaml"))
-		}
+	if common.ShouldShowTaskOutput() {
+		fmt.Printf("\n")
+		return createInterruptible(ctx, p, env)
 	}
 
-	// Use standardized hook:setup task, fallback to provider-specific task
-	taskName := "hook:setup"
-
-	// Execute: task -t tasks/Taskfile.new.yaml kind:create CLUSTER_NAME=<name>
-	taskArgs := []string{"-t", taskFile}
-	
-	// Add --silent flag by default unless verbose mode is enabled
-	if !common.ShouldShowTaskOutput() {
-		taskArgs = append(taskArgs, "--silent")
-	}
-	
-	taskArgs = append(taskArgs, taskName, fmt.Sprintf("CLUSTER_NAME=%s", cfg.Name))
-	command := exec.Command("task", taskArgs...)
-	command.Dir = pwd
-	
 	// Show spinner while creating cluster
 	spinnerDone := make(chan bool)
+	spinnerStopped := make(chan bool)
 	go func() {
+		defer close(spinnerStopped)
 		frames := []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
 		i := 0
 		for {
@@ -218,63 +326,271 @@ func executeCreateTask(cfg *config.Config) error {
 				fmt.Printf("\r%s %s %s",
 					tui.ProgressBarStyle.Render(frames[i]),
 					tui.InfoLabelStyle.Render("Creating cluster"),
-					tui.InfoValueStyle.Render(cfg.Name))
+					tui.InfoValueStyle.Render(env.Name))
 				i = (i + 1) % len(frames)
 				time.Sleep(100 * time.Millisecond)
 			}
 		}
 	}()
-	
-	// Handle output based on verbose/silent flags
-	var cmdErr error
-	if common.ShouldShowTaskOutput() {
-		// Stop spinner for verbose mode
-		spinnerDone <- true
-		fmt.Printf("\n")
-		
-		// Verbose mode: show all output
-		command.Stdout = os.Stdout
-		command.Stderr = os.Stderr
-		
-		cmdErr = command.Run()
-	} else {
-		// Silent mode: capture output and only show on error
-		output, err := command.CombinedOutput()
-		cmdErr = err
-		
-		// Stop spinner
-		spinnerDone <- true
-		
-		if cmdErr != nil {
-			return fmt.Errorf("task execution failed: %w, output: %s", cmdErr, string(output))
+
+	err := createInterruptible(ctx, p, env)
+
+	// Stop spinner
+	spinnerDone <- true
+	<-spinnerStopped
+
+	if err != nil {
+		return err
+	}
+
+	// Show success message with checkmark
+	fmt.Printf("\r%s %s %s\n", 
+		tui.SuccessStyle.Render("✓"),
+		tui.InfoLabelStyle.Render("Created cluster"),
+		tui.InfoValueStyle.Render(env.Name))
+
+	return nil
+}
+
+// createInterruptible runs the provider's create, reporting an interrupt
+// rather than the error of the tool it stopped
+func createInterruptible(ctx context.Context, p provider.Provider, env *config.Environment) error {
+	err := p.Create(ctx, env)
+	if err != nil && ctx.Err() != nil {
+		return fmt.Errorf("interrupted")
+	}
+	return err
+}
+
+// openCreateLog truncates the create log of an environment and records its
+// path in the environment
+func openCreateLog(env *config.Environment) (*os.File, error) {
+	path := config.LogPath(env.ProjectPath, env.Name, "create")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := env.Update(func(current *config.Environment) error {
+		current.CreateLog = path
+		return nil
+	}); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return file, nil
+}
+
+// provisionEnvironment creates the infrastructure of a provisioning
+// environment whose lock the caller holds, waits until it is ready and marks
+// it active. Without create it picks up infrastructure that already exists,
+// e.g. when resuming an interrupted 'dick new'.
+func provisionEnvironment(cfg *config.Config, p provider.Provider, env *config.Environment, create bool) error {
+	keep := keepFailed(cfg, nil)
+
+	if create {
+		// Hooks label the docker resources they create for 'dick gc' and
+		// write their outputs to a fresh outputs directory
+		if err := provider.WriteLabelFile(env); err != nil {
+			fmt.Printf("%s %v\n", tui.Icon("warning"), err)
 		}
-		
-		// Show success message with checkmark
-		fmt.Printf("\r%s %s %s\n", 
-			tui.SuccessStyle.Render("✓"),
-			tui.InfoLabelStyle.Render("Created cluster"),
-			tui.InfoValueStyle.Render(cfg.Name))
+		if err := provider.PrepareOutputs(env); err != nil {
+			fmt.Printf("%s %v\n", tui.Icon("warning"), err)
+		}
+		if err := runHook(p, env, provider.HookPreCreate); err != nil {
+			return failEnvironment(cfg, env, keep, err)
+		}
+		if err := executeCreateTask(p, env); err != nil {
+			return failEnvironment(cfg, env, keep, fmt.Errorf("failed to create cluster: %w", err))
+		}
+	}
+
+	// Capture the values the provider exposes, e.g. tofu outputs
+	if err := collectOutputs(p, env); err != nil {
+		return failEnvironment(cfg, env, keep, err)
+	}
+
+	if err := waitReady(cfg, p, env); err != nil {
+		return err
 	}
 
-	if cmdErr != nil {
-		return fmt.Errorf("task execution failed: %w", cmdErr)
+	for _, hook := range []provider.Hook{provider.HookHealthcheck, provider.HookPostCreate} {
+		if err := runHook(p, env, hook); err != nil {
+			return failEnvironment(cfg, env, keep, err)
+		}
+	}
+
+	// The post-create hook may have written more outputs
+	if err := collectOutputs(p, env); err != nil {
+		return failEnvironment(cfg, env, keep, err)
+	}
+
+	// Mark cluster as active and save state
+	if err := env.Update(func(current *config.Environment) error {
+		return current.SetActive()
+	}); err != nil {
+		return fmt.Errorf("failed to set cluster active: %w", err)
 	}
 
+	// Schedule an OS-level cleanup job in case the reaper isn't running at expiry
+	if err := cleanup.ScheduleCleanup(cfg, env); err != nil {
+		fmt.Printf("%s Failed to schedule cleanup job: %v\n", tui.Icon("warning"), err)
+	}
+	cfg.PutEnvironment(env)
 	return nil
 }
 
+// collectOutputs stores the outputs of the provider and the hooks of a new
+// environment with it
+func collectOutputs(p provider.Provider, env *config.Environment) error {
+	outputs, err := provider.CollectOutputs(context.Background(), p, env)
+	if err != nil {
+		fmt.Printf("%s Failed to read outputs: %v\n", tui.Icon("warning"), err)
+	}
+	if err := env.Update(func(current *config.Environment) error {
+		current.Outputs = outputs
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to save state: %w", err)
+	}
+	return nil
+}
+
+// runHook runs a lifecycle hook of a new environment. Failures that don't
+// abort the create are reported and dropped, an interrupt always aborts.
+func runHook(p provider.Provider, env *config.Environment, hook provider.Hook) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	err := provider.RunHook(ctx, p, env, hook)
+	if ctx.Err() == context.Canceled {
+		return fmt.Errorf("interrupted during the %s hook", hook)
+	}
+	if err != nil && !provider.IsAbort(err) {
+		fmt.Printf("%s Warning: %v\n", tui.Icon("warning"), err)
+		return nil
+	}
+	return err
+}
+
+// waitReady runs the readiness probes of a new environment. An environment
+// that doesn't pass them in time is marked failed and destroyed, or kept
+// until its TTL expires if keepFailed says so.
+func waitReady(cfg *config.Config, p provider.Provider, env *config.Environment) error {
+	readiness, err := provider.GetReadiness(p, env)
+	if err != nil {
+		return failEnvironment(cfg, env, keepFailed(cfg, nil), fmt.Errorf("failed to read readiness probes: %w", err))
+	}
+	if readiness == nil {
+		return nil
+	}
+
+	fmt.Printf("%s Waiting up to %s for cluster %s to be ready\n",
+		tui.Icon("timer"), readiness.Timeout, tui.InfoValueStyle.Render(env.Name))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := readiness.Wait(ctx, env); err != nil {
+		if ctx.Err() == context.Canceled {
+			err = fmt.Errorf("interrupted while waiting for readiness")
+		}
+		return failEnvironment(cfg, env, keepFailed(cfg, readiness), err)
+	}
+
+	fmt.Printf("%s %s %s\n",
+		tui.SuccessStyle.Render("✓"),
+		tui.InfoLabelStyle.Render("Cluster ready"),
+		tui.InfoValueStyle.Render(env.Name))
+	return nil
+}
+
+// keepFailed decides whether a failed create leaves the environment in place
+// until its TTL: new.on_create_failure keeps it on any failure, the
+// provider's readiness.on_failure only when it failed its readiness probes
+func keepFailed(cfg *config.Config, readiness *provider.Readiness) bool {
+	if cfg.GetEffectiveNewConfig().OnCreateFailure == "keep" {
+		return true
+	}
+	return readiness != nil && readiness.Keep
+}
+
+// failEnvironment marks a new environment, whose lock the caller holds, as
+// failed and destroys it unless it is kept. A kept environment, or one that
+// can't be destroyed, is left to the reaper and the cleanup job at expiry.
+func failEnvironment(cfg *config.Config, env *config.Environment, keep bool, reason error) error {
+	if err := env.Update(func(current *config.Environment) error {
+		current.SetFailed(reason.Error())
+		return nil
+	}); err != nil {
+		fmt.Printf("%s Failed to save state: %v\n", tui.Icon("warning"), err)
+		env.SetFailed(reason.Error())
+	}
+	cfg.PutEnvironment(env)
+
+	fmt.Printf("%s Cluster %s failed: %v\n", tui.Icon("error"), tui.InfoValueStyle.Render(env.Name), reason)
+	if env.CreateLog != "" {
+		fmt.Printf("%s Output of the create is kept in %s\n", tui.Icon("info"), env.CreateLog)
+	}
+
+	if !keep {
+		fmt.Printf("%s Destroying cluster %s...\n", tui.Icon("destroy"), tui.InfoValueStyle.Render(env.Name))
+		err := cleanup.DestroyLocked(env)
+		if err == nil {
+			return fmt.Errorf("cluster '%s' failed and was destroyed", env.Name)
+		}
+		fmt.Printf("%s Failed to destroy cluster: %v\n", tui.Icon("warning"), err)
+	}
+
+	fmt.Printf("%s Keeping cluster %s, it is destroyed when its TTL expires at %s\n",
+		tui.Icon("warning"),
+		tui.InfoValueStyle.Render(env.Name),
+		tui.WarningStyle.Render(env.ExpiresAt.Format("15:04:05")))
+
+	if err := cleanup.ScheduleCleanup(cfg, env); err != nil {
+		fmt.Printf("%s Failed to schedule cleanup job: %v\n", tui.Icon("warning"), err)
+	}
+	if err := cleanup.EnsureReaper(); err != nil {
+		fmt.Printf("%s Failed to start the reaper: %v\n", tui.Icon("warning"), err)
+	}
+
+	return fmt.Errorf("cluster '%s' failed and was kept", env.Name)
+}
+
+// sortedKeys returns the keys of a string map in sorted order
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// formatParams renders parameters as name=value pairs sorted by name
+func formatParams(params map[string]string) string {
+	pairs := make([]string, 0, len(params))
+	for _, key := range sortedKeys(params) {
+		pairs = append(pairs, key+"="+params[key])
+	}
+	return strings.Join(pairs, " ")
+}
+
 // startWatchMode starts the TUI watch interface
-func startWatchMode(cfg *config.Config) error {
+func startWatchMode(cfg *config.Config, env *config.Environment) error {
 	// Use the existing status command watch mode
 	statusOpts := StatusOptions{
 		Config: cfg,
+		Name:   env.Name,
 		Watch:  true,
 	}
 	return RunStatus(statusOpts)
 }
 
 // waitForCleanup blocks until the cluster is cleaned up or the process is interrupted
-func waitForCleanup(cfg *config.Config) error {
+func waitForCleanup(env *config.Environment) error {
 	// Set up signal handling for graceful shutdown
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
@@ -291,19 +607,19 @@ func waitForCleanup(cfg *config.Config) error {
 			select {
 			case <-ticker.C:
 				// Reload config to check current status
-				currentConfig, err := config.LoadConfig()
+				current, err := config.LoadEnvironment(env.Name)
 				if err != nil {
 					continue
 				}
 				
 				// Check if cluster has been destroyed
-				if currentConfig.Status == "destroyed" {
+				if current.Status == "destroyed" {
 					cleanupDone <- true
 					return
 				}
 				
 				// Check if we've passed the expiration time
-				if time.Now().After(currentConfig.ExpiresAt) {
+				if time.Now().After(current.ExpiresAt) {
 					// Give a bit more time for cleanup to complete
 					time.Sleep(10 * time.Second)
 					cleanupDone <- true
@@ -321,7 +637,12 @@ func waitForCleanup(cfg *config.Config) error {
 		for {
 			select {
 			case <-ticker.C:
-				remaining := cfg.TimeRemaining()
+				// 'dick extend' may have moved the expiry
+				current, err := config.ReadEnvironment(env.ProjectPath, env.Name)
+				if err != nil || current == nil {
+					continue
+				}
+				remaining := current.TimeRemaining()
 				if remaining > 0 {
 					fmt.Printf("%s Time remaining: %s\n", 
 						tui.Icon("timer"), 
@@ -343,7 +664,7 @@ func waitForCleanup(cfg *config.Config) error {
 		fmt.Printf("\n%s Received signal %v, exiting...\n", tui.Icon("warning"), sig)
 		fmt.Printf("%s Scheduled cleanup will still occur at %s\n", 
 			tui.Icon("info"), 
-			tui.InfoValueStyle.Render(cfg.ExpiresAt.Format("15:04:05")))
+			tui.InfoValueStyle.Render(env.ExpiresAt.Format("15:04:05")))
 		return nil
 	}
 }
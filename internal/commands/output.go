@@ -0,0 +1,42 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/killallgit/dick/internal/config"
+)
+
+// OutputOptions holds configuration for the output command
+type OutputOptions struct {
+	Config *config.Config
+	Name   string
+	Key    string
+}
+
+// RunOutput prints the outputs of an environment as key=value lines, or the
+// bare value of a single key for use in scripts
+func RunOutput(opts OutputOptions) error {
+	env, err := opts.Config.ResolveEnvironment(opts.Name)
+	if err != nil {
+		return err
+	}
+
+	if opts.Key == "" {
+		for _, key := range sortedKeys(env.Outputs) {
+			fmt.Printf("%s=%s\n", key, env.Outputs[key])
+		}
+		return nil
+	}
+
+	value, ok := env.Outputs[opts.Key]
+	if !ok {
+		known := "none"
+		if len(env.Outputs) > 0 {
+			known = strings.Join(sortedKeys(env.Outputs), ", ")
+		}
+		return fmt.Errorf("environment '%s' has no output '%s' (known: %s)", env.Name, opts.Key, known)
+	}
+	fmt.Println(value)
+	return nil
+}
@@ -0,0 +1,107 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/killallgit/dick/internal/cleanup"
+	"github.com/killallgit/dick/internal/config"
+	"github.com/killallgit/dick/internal/tui"
+)
+
+// ReaperOptions holds configuration for the reaper command
+type ReaperOptions struct {
+	StayAlive bool
+}
+
+// RunReaper runs the reaper loop in the foreground until interrupted
+func RunReaper(opts ReaperOptions) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	err := cleanup.RunReaper(ctx, cleanup.ReaperOptions{StayAlive: opts.StayAlive})
+	if errors.Is(err, cleanup.ErrReaperRunning) {
+		fmt.Printf("%s Reaper is already running\n", tui.Icon("info"))
+		return nil
+	}
+	return err
+}
+
+// RunReaperStart starts the reaper in the background if it isn't running
+func RunReaperStart() error {
+	if err := cleanup.EnsureReaper(); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s Reaper is running in the background\n", tui.Icon("success"))
+	return nil
+}
+
+// RunReaperStop stops the background reaper
+func RunReaperStop() error {
+	status, err := cleanup.GetReaperStatus()
+	if err != nil {
+		return err
+	}
+	if !status.Running {
+		fmt.Printf("%s Reaper is not running\n", tui.Icon("info"))
+		return nil
+	}
+
+	if err := cleanup.StopReaper(); err != nil {
+		return fmt.Errorf("failed to stop reaper: %w", err)
+	}
+
+	fmt.Printf("%s Reaper stopped\n", tui.Icon("success"))
+	return nil
+}
+
+// RunReaperStatus shows whether the reaper runs and which environments it watches
+func RunReaperStatus() error {
+	status, err := cleanup.GetReaperStatus()
+	if err != nil {
+		return err
+	}
+
+	if status.Running {
+		fmt.Printf("%s %s %s\n",
+			tui.Icon("active"),
+			tui.InfoLabelStyle.Render("Reaper:"),
+			tui.StatusActiveStyle.Render(fmt.Sprintf("running (pid %d)", status.PID)))
+	} else {
+		fmt.Printf("%s %s %s\n",
+			tui.Icon("destroyed"),
+			tui.InfoLabelStyle.Render("Reaper:"),
+			tui.StatusDestroyedStyle.Render("not running"))
+	}
+	fmt.Printf("%s %s %s\n",
+		tui.Icon("info"),
+		tui.InfoLabelStyle.Render("Log:"),
+		tui.InfoValueStyle.Render(status.LogPath))
+
+	projects, err := config.KnownProjects()
+	if err != nil {
+		return err
+	}
+
+	for _, projectPath := range projects {
+		cfg, err := config.LoadProjectConfig(projectPath)
+		if err != nil {
+			fmt.Printf("%s %s: %v\n", tui.Icon("warning"), projectPath, err)
+			continue
+		}
+
+		for _, env := range cfg.ActiveEnvironments() {
+			fmt.Printf("  • %s %s %s\n",
+				tui.InfoValueStyle.Render(env.Name),
+				tui.ProgressTextStyle.Render(projectPath),
+				tui.WarningStyle.Render("expires "+env.ExpiresAt.Format("2006-01-02 15:04:05")))
+		}
+	}
+
+	return nil
+}
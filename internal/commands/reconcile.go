@@ -0,0 +1,167 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/killallgit/dick/internal/cleanup"
+	"github.com/killallgit/dick/internal/config"
+	"github.com/killallgit/dick/internal/provider"
+	"github.com/killallgit/dick/internal/tui"
+)
+
+// reconcileTimeout bounds the provider checks of the reconcile run by status
+const reconcileTimeout = 15 * time.Second
+
+// ReconcileOptions holds configuration for the reconcile command
+type ReconcileOptions struct {
+	Name   string
+	Output string
+}
+
+// RunReconcile compares the recorded environments with what their providers
+// find and repairs the records
+func RunReconcile(opts ReconcileOptions) error {
+	doc, err := newDocumentWriter(opts.Output)
+	if err != nil {
+		return err
+	}
+	defer doc.Close()
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	envs := cfg.ListEnvironments()
+	if opts.Name != "" {
+		env, err := cfg.ResolveEnvironment(opts.Name)
+		if err != nil {
+			return err
+		}
+		envs = []*config.Environment{env}
+	}
+
+	drifted, err := reconcileEnvironments(context.Background(), envs)
+
+	// Drift found by an earlier run stays until the environment is destroyed
+	for _, env := range envs {
+		if env.IsDrifted() && !slices.Contains(drifted, env) {
+			fmt.Printf("%s Cluster %s is still drifted: %s (run 'dick destroy --name %s')\n",
+				tui.Icon("warning"), tui.InfoValueStyle.Render(env.Name), env.Drift, env.Name)
+			drifted = append(drifted, env)
+		}
+	}
+	if len(drifted) == 0 && err == nil {
+		fmt.Printf("%s No drift found in %d environment(s)\n", tui.Icon("success"), len(envs))
+	}
+
+	if doc.Structured() {
+		if writeErr := doc.Write(envs...); writeErr != nil {
+			return writeErr
+		}
+	}
+	return err
+}
+
+// reconcileEnvironments checks every environment against its provider and
+// reports and repairs each drift. It returns the environments that drifted.
+// Environments another process is working on are skipped.
+func reconcileEnvironments(ctx context.Context, envs []*config.Environment) ([]*config.Environment, error) {
+	var drifted []*config.Environment
+	var errs []error
+
+	for _, env := range envs {
+		if env.InProgress() {
+			continue
+		}
+
+		lock, err := config.LockEnvironment(env.ProjectPath, env.Name)
+		if errors.Is(err, config.ErrLocked) {
+			continue
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", env.Name, err))
+			continue
+		}
+
+		found, err := reconcileEnvironment(ctx, env)
+		lock.Unlock()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", env.Name, err))
+		}
+		if found {
+			drifted = append(drifted, env)
+		}
+	}
+
+	return drifted, errors.Join(errs...)
+}
+
+// reconcileEnvironment compares one environment, whose lock the caller holds,
+// with what its provider finds. Infrastructure that is gone marks the
+// environment destroyed; infrastructure that outlived its teardown marks it
+// drifted. It reports whether the environment drifted.
+func reconcileEnvironment(ctx context.Context, env *config.Environment) (bool, error) {
+	// Pick up changes made since the environments were listed
+	current, err := config.ReadEnvironment(env.ProjectPath, env.Name)
+	if err != nil {
+		return false, err
+	}
+	if current == nil || current.InProgress() {
+		return false, nil
+	}
+	*env = *current
+
+	p, err := provider.Get(env.Provider)
+	if err != nil {
+		return false, err
+	}
+
+	// Providers that can't tell, e.g. Taskfile hooks, never drift
+	state, err := p.Status(ctx, env)
+	if err != nil || state == provider.StateUnknown {
+		return false, err
+	}
+
+	switch {
+	case env.HoldsResources() && state == provider.StateNotFound:
+		if err := cleanup.CancelScheduledCleanup(env); err != nil {
+			fmt.Printf("%s Warning: failed to cancel scheduled cleanup: %v\n", tui.Icon("warning"), err)
+		}
+		drift := fmt.Sprintf("infrastructure not found while %s", env.Status)
+		if err := env.Update(func(current *config.Environment) error {
+			current.SetDestroyed()
+			current.Drift = drift
+			return nil
+		}); err != nil {
+			return true, fmt.Errorf("failed to save state: %w", err)
+		}
+		fmt.Printf("%s Cluster %s: %s, marked destroyed\n",
+			tui.Icon("warning"), tui.InfoValueStyle.Render(env.Name), drift)
+
+	// Records without an ID can't tell their infrastructure from another
+	// that merely has the same name
+	case env.Status == "destroyed" && state == provider.StateRunning && env.ID != "":
+		if err := env.Update(func(current *config.Environment) error {
+			current.SetDrifted("infrastructure still exists after it was destroyed")
+			return nil
+		}); err != nil {
+			return true, fmt.Errorf("failed to save state: %w", err)
+		}
+		fmt.Printf("%s Cluster %s: %s, marked drifted; it is destroyed when its TTL expires at %s (or run 'dick destroy --name %s')\n",
+			tui.Icon("warning"), tui.InfoValueStyle.Render(env.Name), env.Drift,
+			env.ExpiresAt.Format("15:04:05"), env.Name)
+		if err := cleanup.EnsureReaper(); err != nil {
+			fmt.Printf("%s Failed to start the reaper: %v\n", tui.Icon("warning"), err)
+		}
+
+	default:
+		return false, nil
+	}
+
+	return true, nil
+}
@@ -0,0 +1,190 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/killallgit/dick/internal/cleanup"
+	"github.com/killallgit/dick/internal/config"
+	"github.com/killallgit/dick/internal/provider"
+	"github.com/killallgit/dick/internal/tui"
+)
+
+// recoverInterrupted finds environments left provisioning or destroying by a
+// process that died, e.g. a 'dick new' killed during 'kind create', and offers
+// to resume or roll back the interrupted operation. Environments another
+// process is still working on hold their lock and are left alone.
+func recoverInterrupted(cfg *config.Config) error {
+	var errs []error
+
+	for _, env := range cfg.ListEnvironments() {
+		if !env.InProgress() {
+			continue
+		}
+
+		lock, err := config.LockEnvironment(env.ProjectPath, env.Name)
+		if errors.Is(err, config.ErrLocked) {
+			continue
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", env.Name, err))
+			continue
+		}
+
+		err = recoverEnvironment(cfg, env)
+		lock.Unlock()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", env.Name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// recoverEnvironment resumes or rolls back the interrupted operation of an
+// environment whose lock the caller holds
+func recoverEnvironment(cfg *config.Config, env *config.Environment) error {
+	// The operation may have finished between listing and locking
+	current, err := config.ReadEnvironment(env.ProjectPath, env.Name)
+	if err != nil {
+		return err
+	}
+	if current == nil || !current.InProgress() {
+		return nil
+	}
+	*env = *current
+
+	switch env.Status {
+	case "provisioning":
+		resume, err := tui.Confirm(
+			"Interrupted Create",
+			fmt.Sprintf("Creating cluster '%s' was interrupted.\n\n"+
+				"• Yes: Resume, finishing the cluster and waiting until it is ready\n"+
+				"• No: Roll back, destroying whatever was created", env.Name),
+			cfg.Prompts.ResumeCreate,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to show confirmation: %w", err)
+		}
+		if resume {
+			return resumeCreate(cfg, env)
+		}
+
+		fmt.Printf("%s Rolling back cluster %s...\n", tui.Icon("destroy"), tui.InfoValueStyle.Render(env.Name))
+		if err := cleanup.DestroyLocked(env); err != nil {
+			return fmt.Errorf("roll back failed (the TTL still applies): %w", err)
+		}
+		fmt.Printf("%s %s\n", tui.Icon("success"),
+			tui.SuccessStyle.Render(fmt.Sprintf("Cluster '%s' rolled back", env.Name)))
+
+	case "destroying":
+		resume, err := tui.Confirm(
+			"Interrupted Destroy",
+			fmt.Sprintf("Destroying cluster '%s' was interrupted.\n\n"+
+				"• Yes: Resume, destroying the cluster\n"+
+				"• No: Roll back, keeping the cluster until its TTL expires", env.Name),
+			cfg.Prompts.ResumeDestroy,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to show confirmation: %w", err)
+		}
+		if resume {
+			fmt.Printf("%s Resuming destroy of cluster %s...\n", tui.Icon("destroy"), tui.InfoValueStyle.Render(env.Name))
+			if err := cleanup.CancelScheduledCleanup(env); err != nil {
+				fmt.Printf("%s Warning: failed to cancel scheduled cleanup: %v\n", tui.Icon("warning"), err)
+			}
+			if err := cleanup.DestroyLocked(env); err != nil {
+				return fmt.Errorf("destroy failed (the TTL still applies): %w", err)
+			}
+			fmt.Printf("%s %s\n", tui.Icon("success"),
+				tui.SuccessStyle.Render(fmt.Sprintf("Cluster '%s' destroyed", env.Name)))
+			return nil
+		}
+
+		return rollbackDestroy(cfg, env)
+	}
+
+	return nil
+}
+
+// resumeCreate finishes an interrupted create: infrastructure the provider
+// reports running is kept, otherwise it is created again
+func resumeCreate(cfg *config.Config, env *config.Environment) error {
+	p, err := provider.Get(env.Provider)
+	if err != nil {
+		return err
+	}
+	if err := p.Validate(env); err != nil {
+		return fmt.Errorf("provider %s: %w", p.Name(), err)
+	}
+
+	state, err := p.Status(context.Background(), env)
+	if err != nil {
+		fmt.Printf("%s Failed to check cluster: %v\n", tui.Icon("warning"), err)
+	}
+	create := state != provider.StateRunning
+	if create {
+		fmt.Printf("%s Resuming create of cluster %s\n", tui.Icon("info"), tui.InfoValueStyle.Render(env.Name))
+	} else {
+		fmt.Printf("%s Cluster %s exists, finishing its setup\n", tui.Icon("info"), tui.InfoValueStyle.Render(env.Name))
+	}
+
+	if err := provisionEnvironment(cfg, p, env, create); err != nil {
+		return err
+	}
+
+	if err := cleanup.EnsureReaper(); err != nil {
+		fmt.Printf("%s Failed to start the reaper, the cluster will not be destroyed after exit: %v\n",
+			tui.Icon("warning"), err)
+	}
+
+	fmt.Printf("%s %s (expires %s)\n", tui.Icon("success"),
+		tui.SuccessStyle.Render(fmt.Sprintf("Cluster '%s' created", env.Name)),
+		tui.WarningStyle.Render(env.ExpiresAt.Format("15:04:05")))
+	return nil
+}
+
+// rollbackDestroy returns an environment whose teardown was interrupted to
+// its status before, as long as the provider still finds its infrastructure.
+// The TTL keeps applying.
+func rollbackDestroy(cfg *config.Config, env *config.Environment) error {
+	p, err := provider.Get(env.Provider)
+	if err != nil {
+		return err
+	}
+
+	state, err := p.Status(context.Background(), env)
+	if err != nil {
+		fmt.Printf("%s Failed to check cluster: %v\n", tui.Icon("warning"), err)
+	}
+	if state == provider.StateNotFound {
+		fmt.Printf("%s Cluster %s no longer exists, marking it destroyed\n", tui.Icon("info"), tui.InfoValueStyle.Render(env.Name))
+		return env.Update(func(current *config.Environment) error {
+			current.SetDestroyed()
+			return nil
+		})
+	}
+
+	// The teardown may have removed part of the environment
+	if err := env.Update(func(current *config.Environment) error {
+		current.SetFailed("teardown was interrupted")
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to save state: %w", err)
+	}
+	if env.ScheduledJobID == "" && env.TimeRemaining() > 0 {
+		if err := cleanup.ScheduleCleanup(cfg, env); err != nil {
+			fmt.Printf("%s Failed to schedule cleanup job: %v\n", tui.Icon("warning"), err)
+		}
+	}
+	if err := cleanup.EnsureReaper(); err != nil {
+		fmt.Printf("%s Failed to start the reaper: %v\n", tui.Icon("warning"), err)
+	}
+
+	fmt.Printf("%s Kept cluster %s, it is destroyed when its TTL expires at %s\n",
+		tui.Icon("warning"),
+		tui.InfoValueStyle.Render(env.Name),
+		tui.WarningStyle.Render(env.ExpiresAt.Format("15:04:05")))
+	return nil
+}
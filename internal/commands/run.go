@@ -0,0 +1,169 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/killallgit/dick/internal/cleanup"
+	"github.com/killallgit/dick/internal/config"
+	"github.com/killallgit/dick/internal/provider"
+	"github.com/killallgit/dick/internal/tui"
+)
+
+// RunOptions holds configuration for the run command
+type RunOptions struct {
+	Config        *config.Config
+	Provider      string
+	TTL           string
+	Name          string
+	Set           []string
+	Values        []string
+	KeepOnFailure bool
+	Command       []string
+}
+
+// ExitError makes dick exit with Code without printing an error
+type ExitError struct {
+	Code int
+}
+
+// Error implements error
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("exit status %d", e.Code)
+}
+
+// RunRun creates an environment, runs a command against it and destroys it
+// once the command exits. The command's exit status is returned as an
+// ExitError; the TTL still applies if dick itself is killed. No TTL timer
+// runs in dick meanwhile, so the environment is never torn down under the
+// command; the reaper and the cleanup job enforce the TTL.
+func RunRun(opts RunOptions) error {
+	if len(opts.Command) == 0 {
+		return fmt.Errorf("no command given (usage: dick run -- <command> [args...])")
+	}
+	cfg := opts.Config
+
+	// Keep stdout for the command, dick reports on stderr
+	restore := redirectStdout()
+	defer func() { restore() }()
+
+	env, err := createEnvironment(cfg, NewOptions{
+		Provider: opts.Provider,
+		TTL:      opts.TTL,
+		Name:     opts.Name,
+		Set:      opts.Set,
+		Values:   opts.Values,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("\n%s Running %s\n\n", tui.Icon("info"), tui.InfoValueStyle.Render(strings.Join(opts.Command, " ")))
+
+	// Signals go to the command while it runs and are held off while the
+	// cluster is torn down, so a second Ctrl+C can't leave it half destroyed
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, forwardedSignals...)
+	defer signal.Stop(sigChan)
+
+	restore()
+	code, runErr := runChild(opts.Command, provider.Environ(env), sigChan)
+	restore = redirectStdout()
+
+	if runErr != nil {
+		fmt.Printf("%s %v\n", tui.Icon("error"), runErr)
+	} else {
+		fmt.Printf("\n%s Command exited with status %d\n", tui.Icon("info"), code)
+	}
+
+	if code != 0 && opts.KeepOnFailure {
+		fmt.Printf("%s Keeping cluster %s for debugging, it is destroyed when its TTL expires at %s\n",
+			tui.Icon("warning"),
+			tui.InfoValueStyle.Render(env.Name),
+			tui.WarningStyle.Render(env.ExpiresAt.Format("15:04:05")))
+		return &ExitError{Code: code}
+	}
+
+	fmt.Printf("%s Destroying cluster %s...\n", tui.Icon("destroy"), tui.InfoValueStyle.Render(env.Name))
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case <-sigChan:
+				fmt.Printf("%s Still destroying cluster %s, please wait\n",
+					tui.Icon("warning"), tui.InfoValueStyle.Render(env.Name))
+			case <-done:
+				return
+			}
+		}
+	}()
+	if err := cleanup.ForceCleanup(env); err != nil {
+		return fmt.Errorf("failed to destroy cluster (the TTL still applies): %w", err)
+	}
+	fmt.Printf("%s %s\n",
+		tui.Icon("success"),
+		tui.SuccessStyle.Render(fmt.Sprintf("Cluster '%s' destroyed", env.Name)))
+
+	if code != 0 {
+		return &ExitError{Code: code}
+	}
+	return nil
+}
+
+// runChild runs a command with extra environment variables, forwarding the
+// signals dick receives on sigChan, and returns its exit status. Interrupts
+// from the terminal reach a command in the foreground on their own and are
+// not forwarded a second time. A command that can't be started exits with
+// 127 like in a shell.
+func runChild(command []string, extraEnv []string, sigChan <-chan os.Signal) (int, error) {
+	cmd := exec.Command(command[0], command[1:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(), extraEnv...)
+
+	if err := cmd.Start(); err != nil {
+		return 127, fmt.Errorf("failed to start %s: %w", command[0], err)
+	}
+
+	foreground := inForeground()
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case sig := <-sigChan:
+				if sig == os.Interrupt && foreground {
+					continue
+				}
+				// The command may already be gone or not support the signal
+				_ = cmd.Process.Signal(sig)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	err := cmd.Wait()
+	close(done)
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+			return 128 + int(status.Signal()), nil
+		}
+		return exitErr.ExitCode(), nil
+	}
+	if err != nil {
+		return 1, err
+	}
+	return 0, nil
+}
+
+// forwardedSignals are passed on to the command run by 'dick run'
+var forwardedSignals = []os.Signal{os.Interrupt, syscall.SIGTERM}
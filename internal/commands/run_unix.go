@@ -0,0 +1,23 @@
+//go:build !windows
+
+package commands
+
+import (
+	"os"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// inForeground reports whether dick runs in the foreground process group of
+// its controlling terminal, whose interrupts reach the commands it runs too
+func inForeground() bool {
+	tty, err := os.Open("/dev/tty")
+	if err != nil {
+		return false
+	}
+	defer tty.Close()
+
+	pgrp, err := unix.IoctlGetInt(int(tty.Fd()), unix.TIOCGPGRP)
+	return err == nil && pgrp == syscall.Getpgrp()
+}
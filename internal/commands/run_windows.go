@@ -0,0 +1,9 @@
+//go:build windows
+
+package commands
+
+// inForeground reports whether the interrupts dick gets reach the commands it
+// runs too, which is always the case for the processes of a console
+func inForeground() bool {
+	return true
+}
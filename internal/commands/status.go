@@ -1,7 +1,9 @@
 package commands
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/killallgit/dick/internal/cleanup"
@@ -13,16 +15,44 @@ import (
 // StatusOptions holds configuration for the status command
 type StatusOptions struct {
 	Config *config.Config
+	Name   string
 	Watch  bool
+	Output string
+
+	// Reconcile compares the environments with their providers first
+	Reconcile bool
 }
 
 // RunStatus executes the status command with the given options
 func RunStatus(opts StatusOptions) error {
 	cfg := opts.Config
 
+	doc, err := newDocumentWriter(opts.Output)
+	if err != nil {
+		return err
+	}
+	defer doc.Close()
+	if doc.Structured() && opts.Watch {
+		return fmt.Errorf("--output %s cannot be used with --watch", opts.Output)
+	}
+
+	// Resume or roll back operations of a dick that was killed
+	if err := recoverInterrupted(cfg); err != nil {
+		fmt.Printf("%s Warning: recovering interrupted operations failed: %v\n", tui.Icon("warning"), err)
+	}
+
+	if opts.Reconcile {
+		ctx, cancel := context.WithTimeout(context.Background(), reconcileTimeout)
+		_, err := reconcileEnvironments(ctx, cfg.ListEnvironments())
+		cancel()
+		if err != nil {
+			fmt.Printf("%s Warning: reconcile failed: %v\n", tui.Icon("warning"), err)
+		}
+	}
+
 	// Check for expired clusters before showing status
 	// This replaces the global pre-run hook and provides better recovery
-	if cfg.Status == "active" {
+	if len(cfg.ActiveEnvironments()) > 0 {
 		if err := checkExpiration(cfg); err != nil {
 			// Don't fail on expiration check errors, just warn
 			fmt.Printf("%s Warning: expiration check failed: %v\n", tui.Icon("warning"), err)
@@ -36,10 +66,31 @@ func RunStatus(opts StatusOptions) error {
 	}
 
 	if opts.Watch {
-		return runWatch(cfg)
+		if !common.IsInteractive() {
+			return fmt.Errorf("--watch needs an interactive terminal")
+		}
+		env, err := cfg.ResolveEnvironment(opts.Name)
+		if err != nil {
+			return err
+		}
+		return runWatch(env)
+	}
+
+	// A name narrows the output to one environment, otherwise list them all
+	envs := cfg.ListEnvironments()
+	if opts.Name != "" {
+		env, err := cfg.ResolveEnvironment(opts.Name)
+		if err != nil {
+			return err
+		}
+		envs = []*config.Environment{env}
+	}
+
+	if doc.Structured() {
+		return doc.Write(envs...)
 	}
 	
-	return runSimple(cfg)
+	return runSimple(cfg, envs)
 }
 
 // checkExpiration handles expired cluster checking for status command
@@ -48,46 +99,66 @@ func checkExpiration(cfg *config.Config) error {
 }
 
 // runSimple shows the basic status output
-func runSimple(cfg *config.Config) error {
+func runSimple(cfg *config.Config, envs []*config.Environment) error {
+	if len(envs) == 0 {
+		fmt.Printf("No active cluster (run 'dick new' to create)\n")
+		return nil
+	}
+
 	if common.ShouldShowTaskOutput() {
 		// Verbose mode - show detailed information
-		return runVerboseStatus(cfg)
+		for i, env := range envs {
+			if i > 0 {
+				fmt.Println()
+			}
+			if err := runVerboseStatus(cfg, env); err != nil {
+				return err
+			}
+		}
+		return nil
 	}
 	
-	// Minimal mode - single line format
-	switch cfg.Status {
+	// Minimal mode - single line format per environment
+	for _, env := range envs {
+		printStatusLine(env)
+	}
+	
+	return nil
+}
+
+// printStatusLine prints the single line status of an environment
+func printStatusLine(env *config.Environment) {
+	switch env.Status {
 	case "active":
-		remaining := cfg.TimeRemaining()
+		remaining := env.TimeRemaining()
 		if remaining > 0 {
-			totalDuration, err := cfg.ParseTTL()
+			totalDuration, err := env.Lifetime()
 			if err != nil {
-				// Fallback to simple format if TTL parsing fails
-				fmt.Printf("%s %s %s\n", cfg.Name, tui.FormatStatus(cfg.Status), remaining.String())
-				return nil
+				// Fallback to simple format if the lifetime is unknown
+				fmt.Printf("%s %s %s\n", env.Name, tui.FormatStatus(env.Status), remaining.String())
+				return
 			}
 			
 			// Show progress bar format: name [progress] time-remaining
-			fmt.Println(tui.RenderProgressBar(cfg.Name, remaining, totalDuration, 20))
+			fmt.Println(tui.RenderProgressBar(env.Name, remaining, totalDuration, 20))
 		} else {
 			// Expired cluster
-			fmt.Printf("%s %s %s\n", cfg.Name, 
+			fmt.Printf("%s %s %s\n", env.Name, 
 				tui.StatusExpiredStyle.Render("EXPIRED"),
 				tui.ProgressTextStyle.Render("overdue"))
 		}
 		
 	case "destroyed":
-		fmt.Printf("%s %s\n", cfg.Name, tui.FormatStatus(cfg.Status))
+		fmt.Printf("%s %s\n", env.Name, tui.FormatStatus(env.Status))
 		
 	default:
-		// No cluster or unknown status
-		fmt.Printf("No active cluster (run 'dick new' to create)\n")
+		// Unknown status
+		fmt.Printf("%s %s\n", env.Name, tui.FormatStatus(env.Status))
 	}
-	
-	return nil
 }
 
 // runVerboseStatus shows detailed status information
-func runVerboseStatus(cfg *config.Config) error {
+func runVerboseStatus(cfg *config.Config, env *config.Environment) error {
 	// Header
 	fmt.Print(tui.HeaderStyle.Render(fmt.Sprintf("%s Dick Cluster Status", tui.Icon("cluster"))))
 	fmt.Println()
@@ -95,51 +166,108 @@ func runVerboseStatus(cfg *config.Config) error {
 	fmt.Println()
 	
 	// Project information
-	projectPath := cfg.ProjectPath
+	projectPath := env.ProjectPath
 	if projectPath == "" {
 		projectPath = "Current directory"
 	}
 	
 	fmt.Printf("%s: %s\n", tui.Icon("project"), projectPath)
-	fmt.Printf("%s: %s\n", tui.Icon("name"), cfg.Name)
-	fmt.Printf("%s: %s\n", tui.Icon("cluster"), cfg.Provider)
-	fmt.Printf("%s: %s\n", tui.Icon("ttl"), cfg.TTL)
+	fmt.Printf("%s: %s\n", tui.Icon("name"), env.Name)
+	fmt.Printf("%s: %s\n", tui.Icon("cluster"), env.Provider)
+	fmt.Printf("%s: %s\n", tui.Icon("ttl"), env.TTL)
+	if len(env.Extensions) > 0 {
+		fmt.Printf("%s: +%s (%d extension(s))\n", tui.Icon("timer"),
+			env.ExtendedBy().Round(time.Second), len(env.Extensions))
+	}
 	
 	// Status information
-	fmt.Printf("\n%s: %s\n", tui.Icon("active"), tui.FormatStatus(cfg.Status))
+	fmt.Printf("\n%s: %s\n", tui.Icon("active"), tui.FormatStatus(env.Status))
 
-	switch cfg.Status {
+	switch env.Status {
 	case "active":
-		remaining := cfg.TimeRemaining()
+		remaining := env.TimeRemaining()
 		if remaining > 0 {
-			fmt.Printf("%s: %s\n", tui.Icon("created"), cfg.CreatedAt.Format("2006-01-02 15:04:05"))
-			fmt.Printf("%s: %s\n", tui.Icon("expires"), cfg.ExpiresAt.Format("2006-01-02 15:04:05"))
+			fmt.Printf("%s: %s\n", tui.Icon("created"), env.CreatedAt.Format("2006-01-02 15:04:05"))
+			fmt.Printf("%s: %s\n", tui.Icon("expires"), env.ExpiresAt.Format("2006-01-02 15:04:05"))
 			fmt.Printf("%s: %s\n", tui.Icon("remaining"), remaining.String())
+			showScheduledCleanup(env)
 		} else {
 			fmt.Printf("%s: Should have been destroyed at %s\n", 
 				tui.Icon("warning"),
-				cfg.ExpiresAt.Format("2006-01-02 15:04:05"))
+				env.ExpiresAt.Format("2006-01-02 15:04:05"))
 		}
 
+	case "provisioning", "failed":
+		fmt.Printf("%s: %s\n", tui.Icon("created"), env.CreatedAt.Format("2006-01-02 15:04:05"))
+		fmt.Printf("%s: %s\n", tui.Icon("expires"), env.ExpiresAt.Format("2006-01-02 15:04:05"))
+		showScheduledCleanup(env)
+
 	case "destroyed":
-		if !cfg.CreatedAt.IsZero() {
-			fmt.Printf("%s: %s\n", tui.Icon("created"), cfg.CreatedAt.Format("2006-01-02 15:04:05"))
+		if !env.CreatedAt.IsZero() {
+			fmt.Printf("%s: %s\n", tui.Icon("created"), env.CreatedAt.Format("2006-01-02 15:04:05"))
 		}
 
 	default:
 		fmt.Printf("%s: Run 'dick new' to create a cluster\n", tui.Icon("info"))
 	}
 
+	if env.FailureReason != "" {
+		fmt.Printf("%s: %s\n", tui.Icon("error"), env.FailureReason)
+		if env.CreateLog != "" {
+			fmt.Printf("%s: Output of the create is kept in %s\n", tui.Icon("info"), env.CreateLog)
+		}
+	}
+
+	// Parameters the environment was created with
+	if len(env.Params) > 0 {
+		fmt.Println()
+		fmt.Print(tui.TitleStyle.Render(" Params "))
+		fmt.Println()
+		for _, key := range sortedKeys(env.Params) {
+			fmt.Printf("  • %s %s\n",
+				tui.InfoLabelStyle.Render(key+":"),
+				tui.InfoValueStyle.Render(env.Params[key]))
+		}
+	}
+
+	// Provider outputs
+	if len(env.Outputs) > 0 {
+		fmt.Println()
+		fmt.Print(tui.TitleStyle.Render(" Outputs "))
+		fmt.Println()
+		for _, key := range sortedKeys(env.Outputs) {
+			fmt.Printf("  • %s %s\n", 
+				tui.InfoLabelStyle.Render(key+":"), 
+				tui.InfoValueStyle.Render(env.Outputs[key]))
+		}
+	}
+
 	// Configuration options
 	fmt.Println()
-	showConfigOptions(cfg)
+	showConfigOptions(cfg, env)
 	
 	return nil
 }
 
+// showScheduledCleanup reports the OS-level cleanup job of an active environment
+func showScheduledCleanup(env *config.Environment) {
+	if env.ScheduledJobID == "" {
+		fmt.Printf("%s: none scheduled (relying on the reaper)\n", tui.Icon("timer"))
+		return
+	}
+
+	state := "pending"
+	if exists, err := cleanup.CheckScheduledCleanup(env); err != nil {
+		state = fmt.Sprintf("unknown (%v)", err)
+	} else if !exists {
+		state = "missing"
+	}
+	fmt.Printf("%s: %s job %s (%s)\n", tui.Icon("timer"), env.Scheduler, env.ScheduledJobID, state)
+}
+
 // runWatch shows the continuous monitoring TUI
-func runWatch(cfg *config.Config) error {
-	model := tui.NewModel(cfg, true) // true for watch mode
+func runWatch(env *config.Environment) error {
+	model := tui.NewModel(env, true) // true for watch mode
 	p := tea.NewProgram(model, tea.WithAltScreen())
 	
 	if _, err := p.Run(); err != nil {
@@ -150,7 +278,7 @@ func runWatch(cfg *config.Config) error {
 }
 
 // showConfigOptions displays configuration options
-func showConfigOptions(cfg *config.Config) {
+func showConfigOptions(cfg *config.Config, env *config.Environment) {
 	fmt.Print(tui.TitleStyle.Render(" Configuration Options "))
 	fmt.Println()
 
@@ -163,10 +291,19 @@ func showConfigOptions(cfg *config.Config) {
 		tui.InfoLabelStyle.Render("Force mode:"), 
 		tui.InfoValueStyle.Render(forceStatus))
 	
+	// Cleanup scheduler
+	scheduler := cfg.Cleanup.Scheduler
+	if scheduler == "" {
+		scheduler = "auto"
+	}
+	fmt.Printf("  • %s %s\n", 
+		tui.InfoLabelStyle.Render("Cleanup scheduler:"), 
+		tui.InfoValueStyle.Render(scheduler))
+	
 	// Cleanup attempts
-	if cfg.CleanupAttempts > 0 {
+	if env.CleanupAttempts > 0 {
 		fmt.Printf("  • %s %s\n", 
 			tui.InfoLabelStyle.Render("Cleanup status:"), 
-			tui.InfoValueStyle.Render(cfg.GetCleanupStatus()))
+			tui.InfoValueStyle.Render(env.GetCleanupStatus()))
 	}
 }
\ No newline at end of file
@@ -0,0 +1,237 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/killallgit/dick/internal/config"
+	"github.com/killallgit/dick/internal/provider"
+	"github.com/killallgit/dick/internal/tui"
+)
+
+// Exit codes of 'dick wait' besides 0 (condition met) and 1 (error)
+const (
+	// WaitExitTimeout means the timeout passed before the condition was met
+	WaitExitTimeout = 2
+	// WaitExitFailed means the environment reached a state the condition
+	// can no longer be met from, e.g. destroyed while waiting for ready
+	WaitExitFailed = 3
+)
+
+// WaitOptions holds configuration for the wait command
+type WaitOptions struct {
+	Name     string
+	For      string
+	Timeout  time.Duration
+	Interval time.Duration
+	Probe    bool
+}
+
+// waitCondition is a parsed --for value
+type waitCondition struct {
+	kind   string        // ready, destroyed or expiring
+	within time.Duration // for expiring
+
+	// stale identifies the destroyed or failed record the environment had
+	// when the wait started. Waiting for ready, it stands for an environment
+	// not created yet, e.g. by a 'dick new --detach' that was just started.
+	stale *config.Environment
+}
+
+// startedFrom remembers the record found when the wait starts
+func (c *waitCondition) startedFrom(env *config.Environment) {
+	if env != nil && (env.Status == "destroyed" || env.Status == "failed") {
+		c.stale = env
+	}
+}
+
+// isStale reports whether env is still the record found when the wait started
+func (c waitCondition) isStale(env *config.Environment) bool {
+	return c.stale != nil && env.ID == c.stale.ID && env.CreatedAt.Equal(c.stale.CreatedAt)
+}
+
+// String returns the condition as shown to the user
+func (c waitCondition) String() string {
+	if c.kind == "expiring" {
+		return fmt.Sprintf("expiring within %s", c.within)
+	}
+	return c.kind
+}
+
+// errUnreachable is returned when a condition can no longer be met
+var errUnreachable = errors.New("condition can no longer be met")
+
+// parseWaitCondition parses ready, destroyed or expiring=<duration>
+func parseWaitCondition(value string) (waitCondition, error) {
+	kind, arg, hasArg := strings.Cut(value, "=")
+	switch kind {
+	case "ready", "destroyed":
+		if hasArg {
+			return waitCondition{}, fmt.Errorf("condition '%s' takes no value", kind)
+		}
+		return waitCondition{kind: kind}, nil
+	case "expiring":
+		if !hasArg {
+			return waitCondition{}, fmt.Errorf("condition 'expiring' needs a duration, e.g. expiring=5m")
+		}
+		within, err := time.ParseDuration(arg)
+		if err != nil || within < 0 {
+			return waitCondition{}, fmt.Errorf("invalid duration '%s' (examples: 5m, 1h)", arg)
+		}
+		return waitCondition{kind: kind, within: within}, nil
+	}
+	return waitCondition{}, fmt.Errorf("unsupported condition '%s' (supported: ready, destroyed, expiring=<duration>)", value)
+}
+
+// check reports whether the condition holds for env, which is nil if the
+// environment isn't registered (yet). It returns errUnreachable once the
+// condition can no longer be met.
+func (c waitCondition) check(ctx context.Context, env *config.Environment, probe bool) (bool, error) {
+	if env == nil {
+		if c.kind == "ready" {
+			return false, nil
+		}
+		return false, fmt.Errorf("%w: environment not found", errUnreachable)
+	}
+
+	switch c.kind {
+	case "ready":
+		if c.isStale(env) {
+			return false, nil
+		}
+		if env.FailureReason != "" && (env.Status == "failed" || env.Status == "destroyed") {
+			return false, fmt.Errorf("%w: cluster %s failed: %s", errUnreachable, env.Name, env.FailureReason)
+		}
+		if env.Status == "destroyed" {
+			return false, fmt.Errorf("%w: cluster %s was destroyed", errUnreachable, env.Name)
+		}
+		if !env.IsActive() {
+			return false, nil
+		}
+		if expired, _ := env.CheckExpiration(); expired {
+			return false, fmt.Errorf("%w: cluster %s has expired", errUnreachable, env.Name)
+		}
+		if !probe {
+			return true, nil
+		}
+		return probeRunning(ctx, env), nil
+
+	case "destroyed":
+		return env.Status == "destroyed", nil
+
+	case "expiring":
+		if env.Status == "destroyed" {
+			return false, fmt.Errorf("%w: cluster %s was destroyed", errUnreachable, env.Name)
+		}
+		return env.IsActive() && env.TimeRemaining() <= c.within, nil
+	}
+
+	return false, fmt.Errorf("unsupported condition '%s'", c.kind)
+}
+
+// probeRunning asks the provider whether the infrastructure exists
+func probeRunning(ctx context.Context, env *config.Environment) bool {
+	p, err := provider.Get(env.Provider)
+	if err != nil {
+		return false
+	}
+	state, err := p.Status(ctx, env)
+	return err == nil && state == provider.StateRunning
+}
+
+// RunWait blocks until an environment meets a condition. Timeouts and
+// unreachable conditions are returned as an ExitError with WaitExitTimeout
+// or WaitExitFailed.
+func RunWait(opts WaitOptions) error {
+	condition, err := parseWaitCondition(opts.For)
+	if err != nil {
+		return err
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = 2 * time.Second
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var timeout <-chan time.Time
+	if opts.Timeout > 0 {
+		timer := time.NewTimer(opts.Timeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	ticker := time.NewTicker(opts.Interval)
+	defer ticker.Stop()
+
+	name := opts.Name
+	announced := false
+	for first := true; ; first = false {
+		env, err := lookupWaitEnvironment(name)
+		if err != nil {
+			return err
+		}
+		if first {
+			condition.startedFrom(env)
+		}
+		if env == nil && name == "" && condition.kind != "ready" {
+			return fmt.Errorf("no active environment to wait for")
+		}
+		if env != nil {
+			// Keep following the same environment once it is known
+			name = env.Name
+		}
+
+		if !announced && name != "" {
+			fmt.Printf("%s Waiting for cluster %s to be %s\n",
+				tui.Icon("timer"), tui.InfoValueStyle.Render(name), condition)
+			announced = true
+		}
+
+		met, err := condition.check(ctx, env, opts.Probe)
+		if errors.Is(err, errUnreachable) {
+			fmt.Printf("%s %v\n", tui.Icon("error"), err)
+			return &ExitError{Code: WaitExitFailed}
+		}
+		if err != nil {
+			return err
+		}
+		if met {
+			fmt.Printf("%s Cluster %s is %s\n", tui.Icon("success"), tui.InfoValueStyle.Render(name), condition)
+			return nil
+		}
+
+		select {
+		case <-ticker.C:
+		case <-timeout:
+			fmt.Printf("%s Timed out after %s waiting for %s\n", tui.Icon("warning"), opts.Timeout, condition)
+			return &ExitError{Code: WaitExitTimeout}
+		case <-ctx.Done():
+			return fmt.Errorf("interrupted")
+		}
+	}
+}
+
+// lookupWaitEnvironment reads the latest record of the named environment, or
+// of the most recent active one if no name is known yet. It returns nil if
+// there is no such environment.
+func lookupWaitEnvironment(name string) (*config.Environment, error) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if name != "" {
+		return cfg.Environment(name), nil
+	}
+	if active := cfg.ActiveEnvironments(); len(active) > 0 {
+		return active[0], nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,134 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/killallgit/dick/internal/config"
+)
+
+func TestParseWaitCondition(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    waitCondition
+		wantErr bool
+	}{
+		{value: "ready", want: waitCondition{kind: "ready"}},
+		{value: "destroyed", want: waitCondition{kind: "destroyed"}},
+		{value: "expiring=5m", want: waitCondition{kind: "expiring", within: 5 * time.Minute}},
+		{value: "expiring=0s", want: waitCondition{kind: "expiring"}},
+		{value: "ready=1m", wantErr: true},
+		{value: "destroyed=", wantErr: true},
+		{value: "expiring", wantErr: true},
+		{value: "expiring=soon", wantErr: true},
+		{value: "expiring=-1m", wantErr: true},
+		{value: "active", wantErr: true},
+		{value: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseWaitCondition(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseWaitCondition(%q) = %+v, want error", tt.value, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseWaitCondition(%q) failed: %v", tt.value, err)
+			}
+			if got != tt.want {
+				t.Errorf("parseWaitCondition(%q) = %+v, want %+v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestWaitConditionCheck covers the outcomes RunWait maps to its exit codes:
+// met (0), not met yet, which ends in WaitExitTimeout, and unreachable, which
+// ends in WaitExitFailed
+func TestWaitConditionCheck(t *testing.T) {
+	const (
+		met         = 0
+		notMet      = WaitExitTimeout
+		unreachable = WaitExitFailed
+	)
+
+	now := time.Now()
+	env := func(status string, expiresIn time.Duration) *config.Environment {
+		return &config.Environment{Name: "dev", Status: status, ExpiresAt: now.Add(expiresIn)}
+	}
+	failed := env("failed", time.Hour)
+	failed.FailureReason = "nodes never became ready"
+	rolledBack := env("destroyed", time.Hour)
+	rolledBack.FailureReason = "nodes never became ready"
+
+	// Records the name had when the wait started, from an earlier run
+	earlier := env("destroyed", -time.Hour)
+	earlier.ID, earlier.CreatedAt = "0123456789ab", now.Add(-2*time.Hour)
+	earlierFailed := env("failed", -time.Hour)
+	earlierFailed.ID, earlierFailed.CreatedAt = "0123456789ab", now.Add(-2*time.Hour)
+	earlierFailed.FailureReason = "nodes never became ready"
+	readySince := func(start *config.Environment) waitCondition {
+		c := waitCondition{kind: "ready"}
+		c.startedFrom(start)
+		return c
+	}
+	recreated := env("provisioning", time.Hour)
+	recreated.ID, recreated.CreatedAt = "ba9876543210", now
+	recreatedFailed := env("destroyed", time.Hour)
+	recreatedFailed.ID, recreatedFailed.CreatedAt = "ba9876543210", now
+
+	tests := []struct {
+		name      string
+		condition waitCondition
+		env       *config.Environment
+		want      int
+	}{
+		{"ready not registered yet", waitCondition{kind: "ready"}, nil, notMet},
+		{"ready provisioning", waitCondition{kind: "ready"}, env("provisioning", time.Hour), notMet},
+		{"ready active", waitCondition{kind: "ready"}, env("active", time.Hour), met},
+		{"ready kept after failing", waitCondition{kind: "ready"}, failed, unreachable},
+		{"ready rolled back", waitCondition{kind: "ready"}, rolledBack, unreachable},
+		{"ready destroyed", waitCondition{kind: "ready"}, env("destroyed", time.Hour), unreachable},
+		{"ready expired", waitCondition{kind: "ready"}, env("active", -time.Minute), unreachable},
+		{"ready destroyed before the wait", readySince(earlier), earlier, notMet},
+		{"ready failed before the wait", readySince(earlierFailed), earlierFailed, notMet},
+		{"ready recreated since the wait", readySince(earlier), recreated, notMet},
+		{"ready recreated and destroyed since the wait", readySince(earlier), recreatedFailed, unreachable},
+		{"destroyed destroyed before the wait", waitCondition{kind: "destroyed", stale: earlier}, earlier, met},
+		{"destroyed not registered", waitCondition{kind: "destroyed"}, nil, unreachable},
+		{"destroyed active", waitCondition{kind: "destroyed"}, env("active", time.Hour), notMet},
+		{"destroyed destroying", waitCondition{kind: "destroyed"}, env("destroying", time.Hour), notMet},
+		{"destroyed destroyed", waitCondition{kind: "destroyed"}, env("destroyed", time.Hour), met},
+		{"expiring not registered", waitCondition{kind: "expiring", within: 5 * time.Minute}, nil, unreachable},
+		{"expiring later", waitCondition{kind: "expiring", within: 5 * time.Minute}, env("active", time.Hour), notMet},
+		{"expiring within", waitCondition{kind: "expiring", within: 5 * time.Minute}, env("active", time.Minute), met},
+		{"expiring provisioning", waitCondition{kind: "expiring", within: 5 * time.Minute}, env("provisioning", time.Minute), notMet},
+		{"expiring destroyed", waitCondition{kind: "expiring", within: 5 * time.Minute}, env("destroyed", time.Minute), unreachable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, err := tt.condition.check(context.Background(), tt.env, false)
+
+			var got int
+			switch {
+			case errors.Is(err, errUnreachable):
+				got = unreachable
+			case err != nil:
+				t.Fatalf("check failed: %v", err)
+			case ok:
+				got = met
+			default:
+				got = notMet
+			}
+			if got != tt.want {
+				t.Errorf("check(%s) gives exit code %d, want %d (met: %v, err: %v)", tt.condition, got, tt.want, ok, err)
+			}
+		})
+	}
+}
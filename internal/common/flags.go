@@ -1,9 +1,20 @@
 package common
 
+import (
+	"os"
+
+	"github.com/charmbracelet/x/term"
+)
+
 // Global flag values set by the root command
 var (
 	VerboseFlag bool
 	SilentFlag  bool
+
+	// YesFlag answers yes to every prompt
+	YesFlag bool
+	// NoInputFlag never prompts, even on a terminal
+	NoInputFlag bool
 )
 
 // IsVerbose returns true if verbose output is enabled
@@ -24,4 +35,13 @@ func ShouldShowTaskOutput() bool {
 		return false
 	}
 	return VerboseFlag
-}
\ No newline at end of file
+}
+
+// IsInteractive reports whether the user can be prompted: --no-input wasn't
+// passed and both stdin and stdout are terminals
+func IsInteractive() bool {
+	if NoInputFlag {
+		return false
+	}
+	return term.IsTerminal(os.Stdin.Fd()) && term.IsTerminal(os.Stdout.Fd())
+}
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -35,16 +36,7 @@ func Initialize(cfgFile string) error {
 		GlobalViper.SetConfigFile(cfgFile)
 	} else {
 		// Search for config in multiple standard locations
-		GlobalViper.SetConfigName(".dick")
-		GlobalViper.SetConfigType("yaml")
-		
-		// Add config search paths in order of precedence
-		GlobalViper.AddConfigPath(".")                    // Current directory
-		if home, err := os.UserHomeDir(); err == nil {
-			GlobalViper.AddConfigPath(home)               // Home directory
-			GlobalViper.AddConfigPath(filepath.Join(home, ".config", "dick")) // XDG config
-		}
-		GlobalViper.AddConfigPath("/etc/dick")           // System-wide config
+		searchConfig(GlobalViper, ".")
 	}
 
 	// Set defaults first
@@ -53,8 +45,8 @@ func Initialize(cfgFile string) error {
 	// Read config file with improved error handling
 	if err := GlobalViper.ReadInConfig(); err != nil {
 		if _, ok := err.(viper.ConfigFileNotFoundError); ok {
-			// Config file not found; create with defaults if we're in a project directory
-			return createDefaultConfigFile()
+			// No config file; run on defaults until 'dick init' creates one
+			return nil
 		}
 		return fmt.Errorf("error reading config file: %w", err)
 	}
@@ -70,13 +62,77 @@ func LoadConfig() (*Config, error) {
 		}
 	}
 
+	return decodeConfig(GlobalViper, "")
+}
+
+// searchConfig makes viper look for .dick.yaml in the project directory and
+// then the user and system-wide locations, in order of precedence
+func searchConfig(v *viper.Viper, projectDir string) {
+	v.SetConfigName(".dick")
+	v.SetConfigType("yaml")
+
+	v.AddConfigPath(projectDir) // Project directory
+	if home, err := os.UserHomeDir(); err == nil {
+		v.AddConfigPath(home)                                  // Home directory
+		v.AddConfigPath(filepath.Join(home, ".config", "dick")) // XDG config
+	}
+	v.AddConfigPath("/etc/dick") // System-wide config
+}
+
+// LoadProjectConfig loads the configuration and state of a project by path
+// without touching the global configuration, e.g. for the reaper. The
+// configuration file is searched for like Initialize does from the project.
+func LoadProjectConfig(projectPath string) (*Config, error) {
+	v := NewIsolatedViper()
+	searchConfig(v, projectPath)
+	if err := v.ReadInConfig(); err != nil {
+		var notFound viper.ConfigFileNotFoundError
+		if !errors.As(err, &notFound) {
+			return nil, fmt.Errorf("error reading config file: %w", err)
+		}
+	}
+
+	return decodeConfig(v, projectPath)
+}
+
+// LoadEnvironmentConfig loads the configuration an environment runs with:
+// that of the configuration file it was created with, e.g. by --config, or
+// else that of its project
+func LoadEnvironmentConfig(env *Environment) (*Config, error) {
+	if env.ConfigFile == "" {
+		return LoadProjectConfig(env.ProjectPath)
+	}
+
+	v := NewIsolatedViper()
+	v.SetConfigFile(env.ConfigFile)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("error reading config file of environment '%s': %w", env.Name, err)
+	}
+
+	return decodeConfig(v, env.ProjectPath)
+}
+
+// decodeConfig unmarshals a viper instance and merges in the project state.
+// An empty projectPath falls back to the configured path or the working directory.
+func decodeConfig(v *viper.Viper, projectPath string) (*Config, error) {
 	var config Config
-	if err := GlobalViper.Unmarshal(&config); err != nil {
+	if err := v.Unmarshal(&config); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
 
-	// Sync between namespaced and legacy fields for compatibility
-	config.SyncLegacyFields()
+	if projectPath != "" {
+		config.ProjectPath = projectPath
+	}
+	if file := v.ConfigFileUsed(); file != "" {
+		if abs, err := filepath.Abs(file); err == nil {
+			file = abs
+		}
+		config.configFile = file
+
+		if err := config.applyDefaultLiterals(file); err != nil {
+			return nil, fmt.Errorf("failed to read parameter defaults: %w", err)
+		}
+	}
 
 	// Set project path if not already set
 	if config.ProjectPath == "" {
@@ -87,9 +143,41 @@ func LoadConfig() (*Config, error) {
 		config.ProjectPath = pwd
 	}
 
+	// Merge runtime state from the state store
+	state, err := ReadState(config.ProjectPath)
+	if err != nil {
+		return nil, err
+	}
+	config.Environments = state.Environments
+
+	// Fold single-environment state from older .dick.yaml files into the state
+	// store the first time a project is loaded. This runs before the legacy
+	// name is overwritten by the namespaced default.
+	if !StateExists(config.ProjectPath) && config.migrateLegacyState() {
+		legacy := config.Environment(config.Name)
+		if _, err := UpdateEnvironment(config.ProjectPath, legacy.Name, func(env *Environment) error {
+			*env = *legacy
+			return nil
+		}); err != nil {
+			return nil, fmt.Errorf("failed to migrate legacy state: %w", err)
+		}
+	}
+
+	// Sync between namespaced and legacy fields for compatibility
+	config.SyncLegacyFields()
+
 	return &config, nil
 }
 
+// LoadEnvironment loads the configuration and resolves the named environment
+func LoadEnvironment(name string) (*Environment, error) {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	return cfg.ResolveEnvironment(name)
+}
+
 // NewIsolatedViper creates a new isolated Viper instance for testing or specific use cases
 // Following modern Viper best practices for multiple instances
 func NewIsolatedViper() *viper.Viper {
@@ -101,37 +189,18 @@ func NewIsolatedViper() *viper.Viper {
 	return v
 }
 
-// SaveConfig writes the configuration back to file
+// SaveConfig writes the declarative configuration back to file.
+// Runtime state is persisted separately with UpdateEnvironment.
 func SaveConfig(config *Config) error {
 	if GlobalViper == nil {
 		return fmt.Errorf("viper not initialized")
 	}
 
-	// Set project path if not already set
-	if config.ProjectPath == "" {
-		pwd, err := os.Getwd()
-		if err != nil {
-			return fmt.Errorf("failed to get current directory: %w", err)
-		}
-		config.ProjectPath = pwd
-	}
-
 	// Update viper with current config values
 	GlobalViper.Set("provider", config.Provider)
 	GlobalViper.Set("ttl", config.TTL)
 	GlobalViper.Set("name", config.Name)
 	GlobalViper.Set("force", config.Force)
-	GlobalViper.Set("status", config.Status)
-	GlobalViper.Set("created_at", config.CreatedAt)
-	GlobalViper.Set("expires_at", config.ExpiresAt)
-	GlobalViper.Set("project_path", config.ProjectPath)
-	GlobalViper.Set("cleanup_attempted", config.CleanupAttempted)
-	GlobalViper.Set("scheduled_job_id", config.ScheduledJobID)
-	
-	// Enhanced cleanup tracking fields
-	GlobalViper.Set("last_cleanup_attempt", config.LastCleanupAttempt)
-	GlobalViper.Set("cleanup_attempts", config.CleanupAttempts)
-	GlobalViper.Set("last_cleanup_error", config.LastCleanupError)
 
 	// Write to config file
 	configFile := GlobalViper.ConfigFileUsed()
@@ -143,21 +212,9 @@ func SaveConfig(config *Config) error {
 	return GlobalViper.WriteConfigAs(configFile)
 }
 
-// createDefaultConfigFile creates a default .dick.yaml file
-func createDefaultConfigFile() error {
-	configPath := ".dick.yaml"
-	
-	// Check if file already exists
-	if _, err := os.Stat(configPath); err == nil {
-		return nil // File already exists
-	}
-
-	// Create default config using viper defaults
-	if err := GlobalViper.SafeWriteConfigAs(configPath); err != nil {
-		return fmt.Errorf("failed to create default config file: %w", err)
-	}
-
-	return nil
+// ConfigFileFound reports whether a config file was loaded
+func ConfigFileFound() bool {
+	return GlobalViper != nil && GlobalViper.ConfigFileUsed() != ""
 }
 
 // GetConfigFilePath returns the path to the active config file
@@ -218,6 +275,18 @@ func BindGlobalFlags(v *viper.Viper, cmd interface{}) error {
 		}
 	}
 
+	if flag := cobraCmd.PersistentFlags().Lookup("yes"); flag != nil {
+		if err := v.BindPFlag("global.yes", flag); err != nil {
+			return fmt.Errorf("failed to bind yes flag: %w", err)
+		}
+	}
+
+	if flag := cobraCmd.PersistentFlags().Lookup("no-input"); flag != nil {
+		if err := v.BindPFlag("global.no_input", flag); err != nil {
+			return fmt.Errorf("failed to bind no-input flag: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -253,6 +322,36 @@ func BindNewFlags(v *viper.Viper, cmd interface{}) error {
 		}
 	}
 
+	if flag := cobraCmd.Flags().Lookup("output"); flag != nil {
+		if err := v.BindPFlag("new.output", flag); err != nil {
+			return fmt.Errorf("failed to bind output flag: %w", err)
+		}
+	}
+
+	if flag := cobraCmd.Flags().Lookup("detach"); flag != nil {
+		if err := v.BindPFlag("new.detach", flag); err != nil {
+			return fmt.Errorf("failed to bind detach flag: %w", err)
+		}
+	}
+
+	if flag := cobraCmd.Flags().Lookup("on-create-failure"); flag != nil {
+		if err := v.BindPFlag("new.on_create_failure", flag); err != nil {
+			return fmt.Errorf("failed to bind on-create-failure flag: %w", err)
+		}
+	}
+
+	if flag := cobraCmd.Flags().Lookup("set"); flag != nil {
+		if err := v.BindPFlag("new.set", flag); err != nil {
+			return fmt.Errorf("failed to bind set flag: %w", err)
+		}
+	}
+
+	if flag := cobraCmd.Flags().Lookup("values"); flag != nil {
+		if err := v.BindPFlag("new.values", flag); err != nil {
+			return fmt.Errorf("failed to bind values flag: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -270,6 +369,24 @@ func BindStatusFlags(v *viper.Viper, cmd interface{}) error {
 		}
 	}
 
+	if flag := cobraCmd.Flags().Lookup("name"); flag != nil {
+		if err := v.BindPFlag("status_cmd.name", flag); err != nil {
+			return fmt.Errorf("failed to bind name flag: %w", err)
+		}
+	}
+
+	if flag := cobraCmd.Flags().Lookup("output"); flag != nil {
+		if err := v.BindPFlag("status_cmd.output", flag); err != nil {
+			return fmt.Errorf("failed to bind output flag: %w", err)
+		}
+	}
+
+	if flag := cobraCmd.Flags().Lookup("reconcile"); flag != nil {
+		if err := v.BindPFlag("status_cmd.reconcile", flag); err != nil {
+			return fmt.Errorf("failed to bind reconcile flag: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -287,6 +404,97 @@ func BindDestroyFlags(v *viper.Viper, cmd interface{}) error {
 		}
 	}
 
+	if flag := cobraCmd.Flags().Lookup("name"); flag != nil {
+		if err := v.BindPFlag("destroy.name", flag); err != nil {
+			return fmt.Errorf("failed to bind name flag: %w", err)
+		}
+	}
+
+	if flag := cobraCmd.Flags().Lookup("output"); flag != nil {
+		if err := v.BindPFlag("destroy.output", flag); err != nil {
+			return fmt.Errorf("failed to bind output flag: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// BindExtendFlags binds 'extend' command flags to Viper with proper namespacing
+func BindExtendFlags(v *viper.Viper, cmd interface{}) error {
+	cobraCmd, ok := cmd.(*cobra.Command)
+	if !ok {
+		return fmt.Errorf("invalid command type, expected *cobra.Command")
+	}
+
+	// Bind extend command flags with namespace
+	if flag := cobraCmd.Flags().Lookup("by"); flag != nil {
+		if err := v.BindPFlag("extend.by", flag); err != nil {
+			return fmt.Errorf("failed to bind by flag: %w", err)
+		}
+	}
+
+	if flag := cobraCmd.Flags().Lookup("until"); flag != nil {
+		if err := v.BindPFlag("extend.until", flag); err != nil {
+			return fmt.Errorf("failed to bind until flag: %w", err)
+		}
+	}
+
+	if flag := cobraCmd.Flags().Lookup("name"); flag != nil {
+		if err := v.BindPFlag("extend.name", flag); err != nil {
+			return fmt.Errorf("failed to bind name flag: %w", err)
+		}
+	}
+
+	if flag := cobraCmd.Flags().Lookup("output"); flag != nil {
+		if err := v.BindPFlag("extend.output", flag); err != nil {
+			return fmt.Errorf("failed to bind output flag: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// BindRunFlags binds 'run' command flags to Viper. The environment flags
+// share the 'new' keys so both commands create environments alike.
+func BindRunFlags(v *viper.Viper, cmd interface{}) error {
+	cobraCmd, ok := cmd.(*cobra.Command)
+	if !ok {
+		return fmt.Errorf("invalid command type, expected *cobra.Command")
+	}
+
+	for _, name := range []string{"ttl", "name", "provider", "on-create-failure", "set", "values"} {
+		if flag := cobraCmd.Flags().Lookup(name); flag != nil {
+			if err := v.BindPFlag("new."+strings.ReplaceAll(name, "-", "_"), flag); err != nil {
+				return fmt.Errorf("failed to bind %s flag: %w", name, err)
+			}
+		}
+	}
+
+	if flag := cobraCmd.Flags().Lookup("keep-on-failure"); flag != nil {
+		if err := v.BindPFlag("run.keep_on_failure", flag); err != nil {
+			return fmt.Errorf("failed to bind keep-on-failure flag: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// BindWaitFlags binds 'wait' command flags to Viper with proper namespacing
+func BindWaitFlags(v *viper.Viper, cmd interface{}) error {
+	cobraCmd, ok := cmd.(*cobra.Command)
+	if !ok {
+		return fmt.Errorf("invalid command type, expected *cobra.Command")
+	}
+
+	// Bind wait command flags with namespace
+	for _, name := range []string{"for", "timeout", "interval", "probe"} {
+		if flag := cobraCmd.Flags().Lookup(name); flag != nil {
+			if err := v.BindPFlag("wait."+name, flag); err != nil {
+				return fmt.Errorf("failed to bind %s flag: %w", name, err)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -304,13 +512,104 @@ func ValidateTTL(ttl string) error {
 	return nil
 }
 
-// ValidateProvider validates a provider name
+// BindReconcileFlags binds 'reconcile' command flags to Viper with proper namespacing
+func BindReconcileFlags(v *viper.Viper, cmd interface{}) error {
+	cobraCmd, ok := cmd.(*cobra.Command)
+	if !ok {
+		return fmt.Errorf("invalid command type, expected *cobra.Command")
+	}
+
+	if flag := cobraCmd.Flags().Lookup("output"); flag != nil {
+		if err := v.BindPFlag("reconcile.output", flag); err != nil {
+			return fmt.Errorf("failed to bind output flag: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// BindOutputFlags binds 'output' command flags to Viper with proper namespacing
+func BindOutputFlags(v *viper.Viper, cmd interface{}) error {
+	cobraCmd, ok := cmd.(*cobra.Command)
+	if !ok {
+		return fmt.Errorf("invalid command type, expected *cobra.Command")
+	}
+
+	if flag := cobraCmd.Flags().Lookup("name"); flag != nil {
+		if err := v.BindPFlag("output_cmd.name", flag); err != nil {
+			return fmt.Errorf("failed to bind name flag: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// BindHooksFlags binds 'hooks' command flags to Viper with proper namespacing
+func BindHooksFlags(v *viper.Viper, cmd interface{}) error {
+	cobraCmd, ok := cmd.(*cobra.Command)
+	if !ok {
+		return fmt.Errorf("invalid command type, expected *cobra.Command")
+	}
+
+	if flag := cobraCmd.Flags().Lookup("provider"); flag != nil {
+		if err := v.BindPFlag("hooks_cmd.provider", flag); err != nil {
+			return fmt.Errorf("failed to bind provider flag: %w", err)
+		}
+	}
+
+	if flag := cobraCmd.Flags().Lookup("force"); flag != nil {
+		if err := v.BindPFlag("hooks_cmd.force", flag); err != nil {
+			return fmt.Errorf("failed to bind force flag: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// BindGCFlags binds 'gc' command flags to Viper with proper namespacing
+func BindGCFlags(v *viper.Viper, cmd interface{}) error {
+	cobraCmd, ok := cmd.(*cobra.Command)
+	if !ok {
+		return fmt.Errorf("invalid command type, expected *cobra.Command")
+	}
+
+	if flag := cobraCmd.Flags().Lookup("dry-run"); flag != nil {
+		if err := v.BindPFlag("gc.dry_run", flag); err != nil {
+			return fmt.Errorf("failed to bind dry-run flag: %w", err)
+		}
+	}
+
+	if flag := cobraCmd.Flags().Lookup("force"); flag != nil {
+		if err := v.BindPFlag("gc.force", flag); err != nil {
+			return fmt.Errorf("failed to bind force flag: %w", err)
+		}
+	}
+
+	if flag := cobraCmd.Flags().Lookup("all"); flag != nil {
+		if err := v.BindPFlag("gc.all", flag); err != nil {
+			return fmt.Errorf("failed to bind all flag: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// providerNames lists the registered providers. The provider package installs
+// it so config doesn't depend on the provider implementations.
+var providerNames = func() []string { return nil }
+
+// SetProviderNames installs the function listing the registered providers
+func SetProviderNames(names func() []string) {
+	providerNames = names
+}
+
+// ValidateProvider validates a provider name against the provider registry
 func ValidateProvider(provider string) error {
 	if provider == "" {
 		return nil // Empty provider is allowed (will use default)
 	}
 	
-	validProviders := []string{"kind", "tofu"}
+	validProviders := providerNames()
 	for _, valid := range validProviders {
 		if provider == valid {
 			return nil
@@ -320,6 +619,92 @@ func ValidateProvider(provider string) error {
 	return fmt.Errorf("unsupported provider '%s' (supported: %s)", provider, strings.Join(validProviders, ", "))
 }
 
+// schedulerNames lists the accepted cleanup schedulers. The cleanup package
+// installs it so config doesn't depend on the scheduler backends.
+var schedulerNames = func() []string { return nil }
+
+// SetSchedulerNames installs the function listing the cleanup schedulers
+func SetSchedulerNames(names func() []string) {
+	schedulerNames = names
+}
+
+// ValidateScheduler validates a cleanup scheduler name
+func ValidateScheduler(scheduler string) error {
+	if scheduler == "" {
+		return nil // Empty scheduler is allowed (will auto-detect)
+	}
+	
+	validSchedulers := schedulerNames()
+	for _, valid := range validSchedulers {
+		if scheduler == valid {
+			return nil
+		}
+	}
+	
+	return fmt.Errorf("unsupported cleanup scheduler '%s' (supported: %s)", scheduler, strings.Join(validSchedulers, ", "))
+}
+
+// ValidateReadiness validates the readiness settings of a provider section
+func ValidateReadiness(section string, readiness ReadinessConfig) error {
+	for key, value := range map[string]string{"timeout": readiness.Timeout, "interval": readiness.Interval} {
+		if value == "" {
+			continue
+		}
+		if d, err := time.ParseDuration(value); err != nil || d <= 0 {
+			return fmt.Errorf("invalid %s.readiness.%s '%s' (examples: 30s, 5m)", section, key, value)
+		}
+	}
+
+	return ValidateFailurePolicy(section+".readiness.on_failure", readiness.OnFailure)
+}
+
+// ValidateHooks validates the timeouts and failure policies of the hooks.
+// Hooks run after the teardown or outside of any operation have nothing to
+// abort.
+func ValidateHooks(hooks HooksConfig) error {
+	for key, hook := range map[string]HookConfig{
+		"pre_create":     hooks.PreCreate,
+		"post_create":    hooks.PostCreate,
+		"healthcheck":    hooks.Healthcheck,
+		"pre_destroy":    hooks.PreDestroy,
+		"post_destroy":   hooks.PostDestroy,
+		"expiry_warning": hooks.ExpiryWarning.HookConfig,
+	} {
+		if hook.Timeout != "" {
+			if d, err := time.ParseDuration(hook.Timeout); err != nil || d <= 0 {
+				return fmt.Errorf("invalid hooks.%s.timeout '%s' (examples: 30s, 5m)", key, hook.Timeout)
+			}
+		}
+		switch hook.OnFailure {
+		case "abort":
+			if key == "post_destroy" || key == "expiry_warning" {
+				return fmt.Errorf("hooks.%s.on_failure can't be abort, the %s hook has nothing to abort (supported: warn, ignore)",
+					key, strings.ReplaceAll(key, "_", "-"))
+			}
+		case "", "warn", "ignore":
+		default:
+			return fmt.Errorf("unsupported hooks.%s.on_failure '%s' (supported: abort, warn, ignore)", key, hook.OnFailure)
+		}
+	}
+
+	if before := hooks.ExpiryWarning.Before; before != "" {
+		if d, err := time.ParseDuration(before); err != nil || d <= 0 {
+			return fmt.Errorf("invalid hooks.expiry_warning.before '%s' (examples: 5m, 1h)", before)
+		}
+	}
+	return nil
+}
+
+// ValidateFailurePolicy validates what to do with an environment that failed
+// to come up: destroy it or keep it until its TTL
+func ValidateFailurePolicy(key, policy string) error {
+	switch policy {
+	case "", "destroy", "keep":
+		return nil
+	}
+	return fmt.Errorf("unsupported %s '%s' (supported: destroy, keep)", key, policy)
+}
+
 // ValidateName validates an environment name
 func ValidateName(name string) error {
 	if name == "" {
@@ -360,5 +745,27 @@ func ValidateConfig(config *Config) error {
 		return err
 	}
 	
+	// Validate cleanup scheduler
+	if err := ValidateScheduler(config.Cleanup.Scheduler); err != nil {
+		return err
+	}
+	
+	// Validate failure handling and readiness probes
+	if err := ValidateFailurePolicy("new.on_create_failure", config.New.OnCreateFailure); err != nil {
+		return err
+	}
+	if err := ValidateReadiness("kind", config.Kind.Readiness); err != nil {
+		return err
+	}
+	if err := ValidateReadiness("tofu", config.Tofu.Readiness); err != nil {
+		return err
+	}
+	if err := ValidateHooks(config.Hooks); err != nil {
+		return err
+	}
+	if err := ValidateParams(config.Params); err != nil {
+		return err
+	}
+	
 	return nil
 }
\ No newline at end of file
@@ -7,18 +7,101 @@ func SetDefaults(v *viper.Viper) {
 	// Global defaults
 	v.SetDefault("global.verbose", false)
 	v.SetDefault("global.silent", false)
+	v.SetDefault("global.yes", false)
+	v.SetDefault("global.no_input", false)
 	
 	// New command defaults  
 	v.SetDefault("new.provider", "kind")
 	v.SetDefault("new.ttl", "5m")
 	v.SetDefault("new.name", "dev-cluster")
 	v.SetDefault("new.force", false)
+	v.SetDefault("new.output", "text")
+	v.SetDefault("new.detach", false)
+	v.SetDefault("new.on_create_failure", "destroy")
 	
 	// Status command defaults
 	v.SetDefault("status_cmd.watch", false)
+	v.SetDefault("status_cmd.name", "")
+	v.SetDefault("status_cmd.output", "text")
+	v.SetDefault("status_cmd.reconcile", true)
 	
 	// Destroy command defaults
 	v.SetDefault("destroy.force", false)
+	v.SetDefault("destroy.name", "")
+	v.SetDefault("destroy.output", "text")
+	
+	// Extend command defaults (empty 'by' extends by the environment's TTL)
+	v.SetDefault("extend.by", "")
+	v.SetDefault("extend.until", "")
+	v.SetDefault("extend.name", "")
+	v.SetDefault("extend.output", "text")
+	
+	// Run command defaults
+	v.SetDefault("run.keep_on_failure", false)
+	
+	// Wait command defaults (empty timeout waits forever)
+	v.SetDefault("wait.for", "ready")
+	v.SetDefault("wait.timeout", "")
+	v.SetDefault("wait.interval", "2s")
+	v.SetDefault("wait.probe", false)
+	
+	// Reconcile command defaults
+	v.SetDefault("reconcile.output", "text")
+	
+	// Output command defaults
+	v.SetDefault("output_cmd.name", "")
+	
+	// Hooks command defaults (empty provider uses new.provider)
+	v.SetDefault("hooks_cmd.provider", "")
+	v.SetDefault("hooks_cmd.force", false)
+	
+	// GC command defaults
+	v.SetDefault("gc.dry_run", false)
+	v.SetDefault("gc.force", false)
+	v.SetDefault("gc.all", false)
+	
+	// Cleanup defaults
+	v.SetDefault("cleanup.scheduler", "auto")
+	
+	// Non-interactive prompt answers
+	v.SetDefault("prompts.destroy_expired", true)
+	v.SetDefault("prompts.destroy", false)
+	v.SetDefault("prompts.resume_create", false)
+	v.SetDefault("prompts.resume_destroy", true)
+	v.SetDefault("prompts.gc", false)
+	
+	// Lifecycle hook defaults: hooks run while the environment is created,
+	// up to its post-create, abort the create; teardown and expiry hooks only
+	// warn, and post-destroy and expiry-warning can't abort
+	v.SetDefault("hooks.pre_create.timeout", "10m")
+	v.SetDefault("hooks.pre_create.on_failure", "abort")
+	v.SetDefault("hooks.post_create.timeout", "10m")
+	v.SetDefault("hooks.post_create.on_failure", "abort")
+	v.SetDefault("hooks.healthcheck.timeout", "2m")
+	v.SetDefault("hooks.healthcheck.on_failure", "abort")
+	v.SetDefault("hooks.pre_destroy.timeout", "5m")
+	v.SetDefault("hooks.pre_destroy.on_failure", "warn")
+	v.SetDefault("hooks.post_destroy.timeout", "5m")
+	v.SetDefault("hooks.post_destroy.on_failure", "warn")
+	v.SetDefault("hooks.expiry_warning.timeout", "1m")
+	v.SetDefault("hooks.expiry_warning.on_failure", "warn")
+	v.SetDefault("hooks.expiry_warning.before", "5m")
+	
+	// Kind provider defaults
+	v.SetDefault("kind.taskfile", false)
+	v.SetDefault("kind.control_planes", 1)
+	v.SetDefault("kind.workers", 0)
+	v.SetDefault("kind.readiness.nodes", true)
+	v.SetDefault("kind.readiness.timeout", "5m")
+	v.SetDefault("kind.readiness.interval", "5s")
+	v.SetDefault("kind.readiness.on_failure", "destroy")
+	
+	// OpenTofu provider defaults
+	v.SetDefault("tofu.module", "tofu")
+	v.SetDefault("tofu.binary", "tofu")
+	v.SetDefault("tofu.readiness.timeout", "5m")
+	v.SetDefault("tofu.readiness.interval", "5s")
+	v.SetDefault("tofu.readiness.on_failure", "destroy")
 	
 	// Legacy defaults for backward compatibility
 	v.SetDefault("provider", "kind")
@@ -0,0 +1,381 @@
+package config
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+)
+
+// Extension records a single push-out of an environment's expiry
+type Extension struct {
+	At                time.Time `json:"at"`
+	PreviousExpiresAt time.Time `json:"previous_expires_at"`
+	ExpiresAt         time.Time `json:"expires_at"`
+}
+
+// Duration returns how much time the extension added
+func (x Extension) Duration() time.Duration {
+	return x.ExpiresAt.Sub(x.PreviousExpiresAt)
+}
+
+// Environment is the lifecycle record of a single named environment
+type Environment struct {
+	// ID is unique to each create and labels the resources of the environment
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Provider    string `json:"provider"`
+	TTL         string `json:"ttl"`
+	ProjectPath string `json:"project_path,omitempty"`
+
+	// The configuration file the environment was created with, if any; its
+	// provider and hook settings are read from it
+	ConfigFile string `json:"config_file,omitempty"`
+
+	// Lifecycle state
+	Status           string    `json:"status,omitempty"`
+	CreatedAt        time.Time `json:"created_at,omitempty"`
+	ExpiresAt        time.Time `json:"expires_at,omitempty"`
+	CleanupAttempted bool      `json:"cleanup_attempted,omitempty"`
+	Scheduler        string    `json:"scheduler,omitempty"`
+	ScheduledJobID   string    `json:"scheduled_job_id,omitempty"`
+
+	// Cleanup tracking
+	LastCleanupAttempt time.Time `json:"last_cleanup_attempt,omitempty"`
+	CleanupAttempts    int       `json:"cleanup_attempts,omitempty"`
+	LastCleanupError   string    `json:"last_cleanup_error,omitempty"`
+
+	// Why the environment failed, e.g. a readiness probe that never passed
+	FailureReason string `json:"failure_reason,omitempty"`
+
+	// Output of the provider tools while the environment was created
+	CreateLog string `json:"create_log,omitempty"`
+
+	// The last difference found between this record and the infrastructure
+	Drift string `json:"drift,omitempty"`
+
+	// The expiry the expiry-warning hook last ran for
+	WarnedExpiry time.Time `json:"warned_expiry,omitempty"`
+
+	// Parameters the environment was created with, passed to its hooks
+	Params map[string]string `json:"params,omitempty"`
+
+	// Values exposed by the provider, e.g. tofu outputs, and by its hooks
+	Outputs map[string]string `json:"outputs,omitempty"`
+
+	// Expiry extensions, oldest first
+	Extensions []Extension `json:"extensions,omitempty"`
+}
+
+// newEnvironmentID returns a random ID for a new environment
+func newEnvironmentID() string {
+	b := make([]byte, 6)
+	if _, err := rand.Read(b); err != nil {
+		// crypto/rand never fails on supported platforms
+		panic(fmt.Sprintf("failed to generate environment ID: %v", err))
+	}
+	return hex.EncodeToString(b)
+}
+
+// Clone returns a copy of the environment that shares nothing with it
+func (e *Environment) Clone() *Environment {
+	clone := *e
+	clone.Params = maps.Clone(e.Params)
+	clone.Outputs = maps.Clone(e.Outputs)
+	clone.Extensions = slices.Clone(e.Extensions)
+	return &clone
+}
+
+// ParseTTL converts the environment TTL string to duration
+func (e *Environment) ParseTTL() (time.Duration, error) {
+	duration, err := time.ParseDuration(e.TTL)
+	if err != nil {
+		return 0, err
+	}
+	return duration, nil
+}
+
+// SetActive marks the environment as active with proper timestamps
+func (e *Environment) SetActive() error {
+	duration, err := e.ParseTTL()
+	if err != nil {
+		return err
+	}
+
+	e.Status = "active"
+	e.CreatedAt = time.Now()
+	e.ExpiresAt = e.CreatedAt.Add(duration)
+	e.CleanupAttempted = false
+	e.CleanupAttempts = 0
+	e.LastCleanupAttempt = time.Time{}
+	e.LastCleanupError = ""
+	e.FailureReason = ""
+	e.Drift = ""
+	e.Extensions = nil
+
+	return nil
+}
+
+// SetProvisioning marks the environment as created but not ready yet. The
+// TTL already runs from now so an environment that never becomes ready still
+// expires; SetActive restarts it once the environment is ready.
+func (e *Environment) SetProvisioning() error {
+	if err := e.SetActive(); err != nil {
+		return err
+	}
+	e.Status = "provisioning"
+	return nil
+}
+
+// SetFailed marks the environment as failed. Its infrastructure is left in
+// place, and destroyed at expiry like that of an active environment.
+func (e *Environment) SetFailed(reason string) {
+	e.Status = "failed"
+	e.FailureReason = reason
+}
+
+// Extend pushes the expiry of an active environment out to expiresAt and
+// records the extension. An already expired environment that hasn't been
+// destroyed yet gets a fresh set of cleanup attempts.
+func (e *Environment) Extend(expiresAt time.Time) error {
+	if e.Status != "active" {
+		return fmt.Errorf("cluster %s is not active (status: %s)", e.Name, e.Status)
+	}
+	if !expiresAt.After(e.ExpiresAt) {
+		return fmt.Errorf("new expiry %s is not after the current expiry %s",
+			expiresAt.Format("2006-01-02 15:04:05"), e.ExpiresAt.Format("2006-01-02 15:04:05"))
+	}
+	if !expiresAt.After(time.Now()) {
+		return fmt.Errorf("new expiry %s is in the past", expiresAt.Format("2006-01-02 15:04:05"))
+	}
+
+	e.Extensions = append(e.Extensions, Extension{
+		At:                time.Now(),
+		PreviousExpiresAt: e.ExpiresAt,
+		ExpiresAt:         expiresAt,
+	})
+	e.ExpiresAt = expiresAt
+	e.CleanupAttempted = false
+	e.CleanupAttempts = 0
+	e.LastCleanupAttempt = time.Time{}
+	e.LastCleanupError = ""
+
+	return nil
+}
+
+// Lifetime returns the total time between creation and expiry, including
+// extensions. It falls back to the TTL for environments that never started.
+func (e *Environment) Lifetime() (time.Duration, error) {
+	if !e.CreatedAt.IsZero() && !e.ExpiresAt.IsZero() {
+		return e.ExpiresAt.Sub(e.CreatedAt), nil
+	}
+	return e.ParseTTL()
+}
+
+// ExtendedBy returns the total time added by extensions
+func (e *Environment) ExtendedBy() time.Duration {
+	var total time.Duration
+	for _, x := range e.Extensions {
+		total += x.Duration()
+	}
+	return total
+}
+
+// SetDrifted marks a destroyed environment whose infrastructure still exists.
+// Its TTL applies again: the infrastructure is destroyed at the recorded
+// expiry, right away if that has passed, with a fresh set of cleanup attempts.
+func (e *Environment) SetDrifted(drift string) {
+	e.Status = "drifted"
+	e.Drift = drift
+	e.CleanupAttempted = false
+	e.CleanupAttempts = 0
+	e.LastCleanupAttempt = time.Time{}
+	e.LastCleanupError = ""
+}
+
+// IsDrifted returns true if the environment was destroyed but its
+// infrastructure still exists
+func (e *Environment) IsDrifted() bool {
+	return e.Status == "drifted"
+}
+
+// SetDestroying marks the environment as being torn down
+func (e *Environment) SetDestroying() {
+	e.Status = "destroying"
+}
+
+// SetDestroyed marks the environment as destroyed
+func (e *Environment) SetDestroyed() {
+	e.Status = "destroyed"
+	e.CleanupAttempted = true
+	e.Outputs = nil
+	e.Drift = ""
+	e.ClearScheduledJob()
+}
+
+// IsActive returns true if the environment is currently active
+func (e *Environment) IsActive() bool {
+	return e.Status == "active"
+}
+
+// HoldsResources returns true if the environment's infrastructure exists and
+// its TTL applies: it is active, being created or torn down, failed and kept,
+// or drifted
+func (e *Environment) HoldsResources() bool {
+	switch e.Status {
+	case "active", "provisioning", "destroying", "failed", "drifted":
+		return true
+	}
+	return false
+}
+
+// InProgress returns true if an operation on the environment was started:
+// it is being created or torn down, or the process doing so was interrupted
+func (e *Environment) InProgress() bool {
+	return e.Status == "provisioning" || e.Status == "destroying"
+}
+
+// IsExpired returns true if the environment has expired
+func (e *Environment) IsExpired() bool {
+	return e.HoldsResources() && time.Now().After(e.ExpiresAt)
+}
+
+// TimeRemaining returns the time until expiration
+func (e *Environment) TimeRemaining() time.Duration {
+	if !e.HoldsResources() {
+		return 0
+	}
+	remaining := e.ExpiresAt.Sub(time.Now())
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+// CheckExpiration returns if the environment is expired and how long it's been expired
+func (e *Environment) CheckExpiration() (expired bool, expiredSince time.Duration) {
+	if !e.HoldsResources() {
+		return false, 0
+	}
+
+	now := time.Now()
+	if now.After(e.ExpiresAt) {
+		return true, now.Sub(e.ExpiresAt)
+	}
+
+	return false, 0
+}
+
+// ShouldPromptDestroy determines if user should be prompted for cleanup
+func (e *Environment) ShouldPromptDestroy(force bool) bool {
+	expired, _ := e.CheckExpiration()
+
+	// Don't prompt if not expired, already destroyed, or cleanup attempted
+	if !expired || !e.HoldsResources() || e.CleanupAttempted {
+		return false
+	}
+
+	// Don't prompt if force mode is enabled
+	if force {
+		return false
+	}
+
+	// Always prompt for manual cleanup when not in force mode
+	return true
+}
+
+// ShouldAutoDestroy determines if automatic cleanup should happen
+func (e *Environment) ShouldAutoDestroy(force bool) bool {
+	expired, _ := e.CheckExpiration()
+
+	// Only auto-destroy if expired, active, and force mode enabled
+	return expired && e.HoldsResources() && force && !e.CleanupAttempted
+}
+
+// ShouldAttemptCleanup determines if we should attempt any form of cleanup
+func (e *Environment) ShouldAttemptCleanup() bool {
+	expired, _ := e.CheckExpiration()
+
+	// Attempt cleanup if expired, active, and not already attempted
+	return expired && e.HoldsResources() && !e.CleanupAttempted
+}
+
+// MarkCleanupAttempted marks that cleanup has been attempted
+func (e *Environment) MarkCleanupAttempted() {
+	e.CleanupAttempted = true
+	e.LastCleanupAttempt = time.Now()
+	e.CleanupAttempts++
+}
+
+// MarkCleanupFailed records a failed cleanup attempt with error details
+func (e *Environment) MarkCleanupFailed(err error) {
+	e.MarkCleanupAttempted()
+	if err != nil {
+		e.LastCleanupError = err.Error()
+	}
+}
+
+// MarkCleanupSuccessful records a successful cleanup attempt
+func (e *Environment) MarkCleanupSuccessful() {
+	e.MarkCleanupAttempted()
+	e.LastCleanupError = ""
+}
+
+// ShouldRetryCleanup determines if cleanup should be retried based on retry policy
+func (e *Environment) ShouldRetryCleanup() bool {
+	expired, _ := e.CheckExpiration()
+
+	// Don't retry if not expired or not active
+	if !expired || !e.HoldsResources() {
+		return false
+	}
+
+	// Don't retry if we've exceeded max attempts
+	const maxCleanupAttempts = 3
+	if e.CleanupAttempts >= maxCleanupAttempts {
+		return false
+	}
+
+	// Don't retry if last attempt was too recent (exponential backoff)
+	if !e.LastCleanupAttempt.IsZero() {
+		timeSinceLastAttempt := time.Since(e.LastCleanupAttempt)
+		minRetryInterval := time.Duration(e.CleanupAttempts+1) * 5 * time.Minute
+
+		if timeSinceLastAttempt < minRetryInterval {
+			return false
+		}
+	}
+
+	return true
+}
+
+// GetCleanupStatus returns a human-readable cleanup status
+func (e *Environment) GetCleanupStatus() string {
+	if e.CleanupAttempts == 0 {
+		return "No cleanup attempts"
+	}
+
+	status := fmt.Sprintf("%d attempt(s)", e.CleanupAttempts)
+	if !e.LastCleanupAttempt.IsZero() {
+		status += fmt.Sprintf(", last: %s", e.LastCleanupAttempt.Format("2006-01-02 15:04:05"))
+	}
+	if e.LastCleanupError != "" {
+		status += fmt.Sprintf(", error: %s", e.LastCleanupError)
+	}
+
+	return status
+}
+
+// SetScheduledJob stores the OS scheduler and the ID of its cleanup job
+func (e *Environment) SetScheduledJob(scheduler, jobID string) {
+	e.Scheduler = scheduler
+	e.ScheduledJobID = jobID
+}
+
+// ClearScheduledJob removes the scheduled job
+func (e *Environment) ClearScheduledJob() {
+	e.Scheduler = ""
+	e.ScheduledJobID = ""
+}
@@ -0,0 +1,73 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/killallgit/dick/internal/common"
+)
+
+// ConfigFileName is the name of the project configuration file
+const ConfigFileName = ".dick.yaml"
+
+// ProjectSettings are the choices 'dick init' writes to a new .dick.yaml
+type ProjectSettings struct {
+	Provider string
+	TTL      string
+	Name     string
+
+	// AutoDestroy destroys expired environments without prompting
+	AutoDestroy bool
+
+	// KindTaskfile makes the kind provider run the Taskfile hooks
+	KindTaskfile bool
+}
+
+// projectFile is the layout of a .dick.yaml written by 'dick init'
+type projectFile struct {
+	New  NewConfig   `yaml:"new"`
+	Kind *KindConfig `yaml:"kind,omitempty"`
+	Tofu *TofuConfig `yaml:"tofu,omitempty"`
+}
+
+// WriteProjectConfig writes a new .dick.yaml into projectDir and returns its
+// path. An existing file is only replaced if overwrite is set.
+func WriteProjectConfig(projectDir string, settings ProjectSettings, overwrite bool) (string, error) {
+	path := filepath.Join(projectDir, ConfigFileName)
+	if _, err := os.Stat(path); err == nil && !overwrite {
+		return path, fmt.Errorf("%s already exists (use --force to overwrite)", path)
+	}
+
+	file := projectFile{
+		New: NewConfig{
+			Provider: settings.Provider,
+			TTL:      settings.TTL,
+			Name:     settings.Name,
+			Force:    settings.AutoDestroy,
+		},
+	}
+	switch settings.Provider {
+	case "kind":
+		if settings.KindTaskfile {
+			file.Kind = &KindConfig{Taskfile: true}
+		}
+	case "tofu":
+		file.Tofu = &TofuConfig{Module: "tofu"}
+	}
+
+	data, err := yaml.Marshal(file)
+	if err != nil {
+		return path, fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	header := "# dick project configuration, see 'dick --help'.\n" +
+		"# Runtime state is kept in .dick/ and never written here.\n"
+	if err := common.WriteFileAtomic(path, append([]byte(header), data...), 0o644); err != nil {
+		return path, fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	return path, nil
+}
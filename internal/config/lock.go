@@ -0,0 +1,85 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ErrLocked is returned by TryLockFile when another process holds the lock
+var ErrLocked = errors.New("lock is held by another process")
+
+// FileLock is an advisory, process-exclusive lock on a file
+type FileLock struct {
+	file *os.File
+}
+
+// LockFile acquires an exclusive lock on path, blocking until it is available.
+// The lock file and its parent directory are created if needed.
+func LockFile(path string) (*FileLock, error) {
+	file, err := openLockFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := lockFile(file, true); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to lock %s: %w", path, err)
+	}
+
+	return &FileLock{file: file}, nil
+}
+
+// TryLockFile acquires an exclusive lock on path without blocking.
+// It returns ErrLocked if the lock is already held.
+func TryLockFile(path string) (*FileLock, error) {
+	file, err := openLockFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := lockFile(file, false); err != nil {
+		file.Close()
+		if errors.Is(err, ErrLocked) {
+			return nil, ErrLocked
+		}
+		return nil, fmt.Errorf("failed to lock %s: %w", path, err)
+	}
+
+	return &FileLock{file: file}, nil
+}
+
+// Unlock releases the lock
+func (l *FileLock) Unlock() error {
+	if l == nil || l.file == nil {
+		return nil
+	}
+
+	unlockErr := unlockFile(l.file)
+	closeErr := l.file.Close()
+	l.file = nil
+
+	return errors.Join(unlockErr, closeErr)
+}
+
+// openLockFile opens (creating if needed) the file used to hold a lock
+func openLockFile(path string) (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create lock directory: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock file: %w", err)
+	}
+
+	return file, nil
+}
+
+// LockEnvironment takes the operation lock of an environment without blocking,
+// so only one process at a time can create or destroy it. It returns ErrLocked
+// if another process is already working on the environment.
+func LockEnvironment(projectPath, name string) (*FileLock, error) {
+	return TryLockFile(filepath.Join(StateDir(projectPath), "locks", name+".lock"))
+}
@@ -0,0 +1,282 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// paramNamePattern matches valid parameter names. Viper lowercases the keys
+// of .dick.yaml, so names are lower case.
+var paramNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// ParamConfig declares a parameter of the project's environments, set with
+// 'dick new --set name=value' or a --values file
+type ParamConfig struct {
+	// Type is string (the default), int, bool or duration
+	Type string `mapstructure:"type" yaml:"type,omitempty"`
+
+	// Default is used when the parameter isn't set. Parameters without a
+	// default are left unset unless Required is true.
+	Default     interface{} `mapstructure:"default" yaml:"default,omitempty"`
+	Required    bool        `mapstructure:"required" yaml:"required,omitempty"`
+	Description string      `mapstructure:"description" yaml:"description,omitempty"`
+
+	// Enum lists the allowed values
+	Enum []string `mapstructure:"enum" yaml:"enum,omitempty"`
+
+	// Pattern is a regular expression string values must match
+	Pattern string `mapstructure:"pattern" yaml:"pattern,omitempty"`
+
+	// Min and Max bound int values
+	Min *int `mapstructure:"min" yaml:"min,omitempty"`
+	Max *int `mapstructure:"max" yaml:"max,omitempty"`
+}
+
+// Normalize checks a value of the parameter and returns it in canonical
+// form, e.g. true for a bool set to 1
+func (p ParamConfig) Normalize(value string) (string, error) {
+	switch p.Type {
+	case "", "string":
+		if p.Pattern != "" {
+			re, err := regexp.Compile(p.Pattern)
+			if err != nil {
+				return "", fmt.Errorf("invalid pattern '%s': %w", p.Pattern, err)
+			}
+			if !re.MatchString(value) {
+				return "", fmt.Errorf("'%s' does not match %s", value, p.Pattern)
+			}
+		}
+
+	case "int":
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return "", fmt.Errorf("'%s' is not an int", value)
+		}
+		if p.Min != nil && n < *p.Min {
+			return "", fmt.Errorf("%d is less than %d", n, *p.Min)
+		}
+		if p.Max != nil && n > *p.Max {
+			return "", fmt.Errorf("%d is greater than %d", n, *p.Max)
+		}
+		value = strconv.Itoa(n)
+
+	case "bool":
+		// YAML 1.1 spellings are accepted since values files often use them
+		switch strings.ToLower(value) {
+		case "yes", "on":
+			value = "true"
+		case "no", "off":
+			value = "false"
+		}
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", fmt.Errorf("'%s' is not a bool", value)
+		}
+		value = strconv.FormatBool(b)
+
+	case "duration":
+		if _, err := time.ParseDuration(value); err != nil {
+			return "", fmt.Errorf("'%s' is not a duration (examples: 30s, 5m)", value)
+		}
+
+	default:
+		return "", fmt.Errorf("unsupported type '%s' (supported: string, int, bool, duration)", p.Type)
+	}
+
+	if len(p.Enum) > 0 {
+		for _, allowed := range p.Enum {
+			if value == allowed {
+				return value, nil
+			}
+		}
+		return "", fmt.Errorf("'%s' is not one of %s", value, strings.Join(p.Enum, ", "))
+	}
+	return value, nil
+}
+
+// ValidateParams validates the parameter declarations of .dick.yaml,
+// including their defaults
+func ValidateParams(params map[string]ParamConfig) error {
+	for _, name := range sortedParamNames(params) {
+		param := params[name]
+		if !paramNamePattern.MatchString(name) {
+			return fmt.Errorf("invalid parameter name '%s' (use lower case letters, digits and underscores)", name)
+		}
+		if (param.Min != nil || param.Max != nil) && param.Type != "int" {
+			return fmt.Errorf("params.%s: min and max only apply to int parameters", name)
+		}
+		if param.Pattern != "" && param.Type != "" && param.Type != "string" {
+			return fmt.Errorf("params.%s: pattern only applies to string parameters", name)
+		}
+
+		switch param.Type {
+		case "", "string", "int", "bool", "duration":
+		default:
+			return fmt.Errorf("unsupported params.%s.type '%s' (supported: string, int, bool, duration)", name, param.Type)
+		}
+		if param.Pattern != "" {
+			if _, err := regexp.Compile(param.Pattern); err != nil {
+				return fmt.Errorf("invalid params.%s.pattern '%s': %w", name, param.Pattern, err)
+			}
+		}
+		switch param.Default.(type) {
+		case map[string]interface{}, []interface{}:
+			return fmt.Errorf("params.%s.default must be a single value", name)
+		}
+		if param.Default != nil {
+			if _, err := param.Normalize(fmt.Sprint(param.Default)); err != nil {
+				return fmt.Errorf("invalid default of params.%s: %w", name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// ResolveParams returns the values of the declared parameters: their
+// defaults, overridden by the values files in order and then by the
+// name=value assignments. Unknown and invalid values are an error, as is a
+// required parameter left unset.
+func ResolveParams(params map[string]ParamConfig, valuesFiles, assignments []string) (map[string]string, error) {
+	values := make(map[string]string)
+
+	for _, file := range valuesFiles {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read values file: %w", err)
+		}
+		// Values keep their literal spelling, e.g. 1.30 stays 1.30
+		var fileValues map[string]yaml.Node
+		if err := yaml.Unmarshal(data, &fileValues); err != nil {
+			return nil, fmt.Errorf("failed to parse values file %s: %w", file, err)
+		}
+		for name, node := range fileValues {
+			value, ok, err := scalarValue(&node)
+			if err != nil {
+				return nil, fmt.Errorf("parameter '%s' in %s %w", name, file, err)
+			}
+			if !ok {
+				continue
+			}
+			values[strings.ToLower(name)] = value
+		}
+	}
+
+	for _, assignment := range assignments {
+		name, value, ok := strings.Cut(assignment, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid --set '%s' (expected name=value)", assignment)
+		}
+		values[strings.ToLower(strings.TrimSpace(name))] = value
+	}
+
+	for name := range values {
+		if _, ok := params[name]; !ok {
+			if len(params) == 0 {
+				return nil, fmt.Errorf("unknown parameter '%s' (no params are declared in .dick.yaml)", name)
+			}
+			return nil, fmt.Errorf("unknown parameter '%s' (declared: %s)", name, strings.Join(sortedParamNames(params), ", "))
+		}
+	}
+
+	resolved := make(map[string]string)
+	for _, name := range sortedParamNames(params) {
+		param := params[name]
+		value, ok := values[name]
+		if !ok {
+			switch {
+			case param.Default != nil:
+				value = fmt.Sprint(param.Default)
+			case param.Required:
+				return nil, fmt.Errorf("parameter '%s' is required (set it with --set %s=<value>)", name, name)
+			default:
+				continue
+			}
+		}
+
+		normalized, err := param.Normalize(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for parameter '%s': %w", name, err)
+		}
+		resolved[name] = normalized
+	}
+
+	if len(resolved) == 0 {
+		return nil, nil
+	}
+	return resolved, nil
+}
+
+// scalarValue returns the literal of a scalar YAML node, false for null. Maps
+// and lists are an error.
+func scalarValue(node *yaml.Node) (string, bool, error) {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if node.Kind != yaml.ScalarNode {
+		return "", false, fmt.Errorf("must be a single value")
+	}
+	if node.Tag == "!!null" {
+		return "", false, nil
+	}
+	return node.Value, true, nil
+}
+
+// applyDefaultLiterals replaces the parameter defaults decoded by viper, which
+// parses 1.30 as the number 1.3, with their literal spelling in the
+// configuration file
+func (c *Config) applyDefaultLiterals(file string) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+
+	var root map[string]yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return err
+	}
+	for key, section := range root {
+		// Viper matches keys case-insensitively
+		if !strings.EqualFold(key, "params") {
+			continue
+		}
+		var declared map[string]map[string]yaml.Node
+		if err := section.Decode(&declared); err != nil {
+			// Viper reports malformed sections
+			return nil
+		}
+		for name, fields := range declared {
+			for field, node := range fields {
+				if !strings.EqualFold(field, "default") {
+					continue
+				}
+				param, ok := c.Params[strings.ToLower(name)]
+				if !ok {
+					continue
+				}
+				// Maps and lists are reported by ValidateParams
+				if value, ok, err := scalarValue(&node); err == nil && ok {
+					param.Default = value
+					c.Params[strings.ToLower(name)] = param
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// sortedParamNames returns the names of the parameters in order
+func sortedParamNames(params map[string]ParamConfig) []string {
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
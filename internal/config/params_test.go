@@ -0,0 +1,226 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParamNormalize(t *testing.T) {
+	one, ten := 1, 10
+
+	tests := []struct {
+		name    string
+		param   ParamConfig
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{name: "string", param: ParamConfig{}, value: "anything", want: "anything"},
+		{name: "string pattern", param: ParamConfig{Pattern: `^v\d+$`}, value: "v12", want: "v12"},
+		{name: "string pattern mismatch", param: ParamConfig{Pattern: `^v\d+$`}, value: "12", wantErr: true},
+		{name: "string enum", param: ParamConfig{Type: "string", Enum: []string{"a", "b"}}, value: "b", want: "b"},
+		{name: "string not in enum", param: ParamConfig{Type: "string", Enum: []string{"a", "b"}}, value: "c", wantErr: true},
+		{name: "int", param: ParamConfig{Type: "int"}, value: "3", want: "3"},
+		{name: "int leading zero", param: ParamConfig{Type: "int"}, value: "007", want: "7"},
+		{name: "int sign", param: ParamConfig{Type: "int"}, value: "+4", want: "4"},
+		{name: "int not a number", param: ParamConfig{Type: "int"}, value: "three", wantErr: true},
+		{name: "int float", param: ParamConfig{Type: "int"}, value: "1.5", wantErr: true},
+		{name: "int in range", param: ParamConfig{Type: "int", Min: &one, Max: &ten}, value: "10", want: "10"},
+		{name: "int below min", param: ParamConfig{Type: "int", Min: &one}, value: "0", wantErr: true},
+		{name: "int above max", param: ParamConfig{Type: "int", Max: &ten}, value: "11", wantErr: true},
+		{name: "int enum after normalizing", param: ParamConfig{Type: "int", Enum: []string{"1", "3"}}, value: "03", want: "3"},
+		{name: "bool true", param: ParamConfig{Type: "bool"}, value: "true", want: "true"},
+		{name: "bool 1", param: ParamConfig{Type: "bool"}, value: "1", want: "true"},
+		{name: "bool 0", param: ParamConfig{Type: "bool"}, value: "0", want: "false"},
+		{name: "bool TRUE", param: ParamConfig{Type: "bool"}, value: "TRUE", want: "true"},
+		{name: "bool yes", param: ParamConfig{Type: "bool"}, value: "yes", want: "true"},
+		{name: "bool On", param: ParamConfig{Type: "bool"}, value: "On", want: "true"},
+		{name: "bool no", param: ParamConfig{Type: "bool"}, value: "no", want: "false"},
+		{name: "bool off", param: ParamConfig{Type: "bool"}, value: "off", want: "false"},
+		{name: "bool invalid", param: ParamConfig{Type: "bool"}, value: "maybe", wantErr: true},
+		{name: "duration", param: ParamConfig{Type: "duration"}, value: "90s", want: "90s"},
+		{name: "duration invalid", param: ParamConfig{Type: "duration"}, value: "90", wantErr: true},
+		{name: "unsupported type", param: ParamConfig{Type: "float"}, value: "1.5", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.param.Normalize(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Normalize(%q) = %q, want error", tt.value, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Normalize(%q) failed: %v", tt.value, err)
+			}
+			if got != tt.want {
+				t.Errorf("Normalize(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveParams(t *testing.T) {
+	dir := t.TempDir()
+	writeValues := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	base := writeValues("base.yaml", "region: eu\nworkers: 2\nmonitoring: yes\n")
+	override := writeValues("override.yaml", "workers: 4\nRegion: us\n")
+	nested := writeValues("nested.yaml", "region:\n  name: eu\n")
+	unset := writeValues("unset.yaml", "region:\n")
+	unknown := writeValues("unknown.yaml", "zone: a\n")
+	literals := writeValues("literals.yaml", "k8s_version: 1.30\nmode: 0755\n")
+	list := writeValues("list.yaml", "region: [eu, us]\n")
+
+	params := map[string]ParamConfig{
+		"region":     {Default: "local"},
+		"workers":    {Type: "int", Default: 1},
+		"monitoring": {Type: "bool", Default: false},
+		"image":      {},
+	}
+
+	tests := []struct {
+		name        string
+		params      map[string]ParamConfig
+		valuesFiles []string
+		assignments []string
+		want        map[string]string
+		wantErr     bool
+	}{
+		{
+			name:   "defaults",
+			params: params,
+			want:   map[string]string{"region": "local", "workers": "1", "monitoring": "false"},
+		},
+		{
+			name:        "values file",
+			params:      params,
+			valuesFiles: []string{base},
+			want:        map[string]string{"region": "eu", "workers": "2", "monitoring": "true"},
+		},
+		{
+			name:        "later values files win",
+			params:      params,
+			valuesFiles: []string{base, override},
+			want:        map[string]string{"region": "us", "workers": "4", "monitoring": "true"},
+		},
+		{
+			name:        "set wins over values files",
+			params:      params,
+			valuesFiles: []string{base, override},
+			assignments: []string{"workers=8", "monitoring=0"},
+			want:        map[string]string{"region": "us", "workers": "8", "monitoring": "false"},
+		},
+		{
+			name:        "later set wins",
+			params:      params,
+			assignments: []string{"workers=2", "workers=3"},
+			want:        map[string]string{"region": "local", "workers": "3", "monitoring": "false"},
+		},
+		{
+			name:        "set names are case insensitive",
+			params:      params,
+			assignments: []string{" Image =kindest/node:v1.31.0"},
+			want:        map[string]string{"region": "local", "workers": "1", "monitoring": "false", "image": "kindest/node:v1.31.0"},
+		},
+		{
+			name:        "set value may contain =",
+			params:      params,
+			assignments: []string{"image=a=b"},
+			want:        map[string]string{"region": "local", "workers": "1", "monitoring": "false", "image": "a=b"},
+		},
+		{
+			name:        "empty values file entry keeps the default",
+			params:      params,
+			valuesFiles: []string{unset},
+			want:        map[string]string{"region": "local", "workers": "1", "monitoring": "false"},
+		},
+		{
+			name:        "values keep their literal spelling",
+			params:      map[string]ParamConfig{"k8s_version": {}, "mode": {}},
+			valuesFiles: []string{literals},
+			want:        map[string]string{"k8s_version": "1.30", "mode": "0755"},
+		},
+		{
+			name:   "nothing declared",
+			params: nil,
+			want:   nil,
+		},
+		{
+			name:    "required",
+			params:  map[string]ParamConfig{"image": {Required: true}},
+			wantErr: true,
+		},
+		{
+			name:        "required set",
+			params:      map[string]ParamConfig{"image": {Required: true}},
+			assignments: []string{"image=busybox"},
+			want:        map[string]string{"image": "busybox"},
+		},
+		{name: "invalid set value", params: params, assignments: []string{"workers=many"}, wantErr: true},
+		{name: "invalid values file value", params: params, valuesFiles: []string{writeValues("bad.yaml", "monitoring: maybe\n")}, wantErr: true},
+		{name: "set without value", params: params, assignments: []string{"workers"}, wantErr: true},
+		{name: "set without name", params: params, assignments: []string{"=3"}, wantErr: true},
+		{name: "unknown set", params: params, assignments: []string{"zone=a"}, wantErr: true},
+		{name: "unknown in values file", params: params, valuesFiles: []string{unknown}, wantErr: true},
+		{name: "set with nothing declared", params: nil, assignments: []string{"zone=a"}, wantErr: true},
+		{name: "nested value", params: params, valuesFiles: []string{nested}, wantErr: true},
+		{name: "list value", params: params, valuesFiles: []string{list}, wantErr: true},
+		{name: "missing values file", params: params, valuesFiles: []string{filepath.Join(dir, "missing.yaml")}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveParams(tt.params, tt.valuesFiles, tt.assignments)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ResolveParams() = %v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveParams() failed: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ResolveParams() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyDefaultLiterals(t *testing.T) {
+	file := filepath.Join(t.TempDir(), ".dick.yaml")
+	content := "params:\n  k8s_version:\n    default: 1.30\n  mode:\n    default: 0755\n  workers:\n    type: int\n    default: 2\n  image: {}\n"
+	if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// As decoded by viper
+	cfg := &Config{Params: map[string]ParamConfig{
+		"k8s_version": {Default: 1.3},
+		"mode":        {Default: 755},
+		"workers":     {Type: "int", Default: 2},
+		"image":       {},
+	}}
+	if err := cfg.applyDefaultLiterals(file); err != nil {
+		t.Fatalf("applyDefaultLiterals() failed: %v", err)
+	}
+
+	got, err := ResolveParams(cfg.Params, nil, nil)
+	if err != nil {
+		t.Fatalf("ResolveParams() failed: %v", err)
+	}
+	want := map[string]string{"k8s_version": "1.30", "mode": "0755", "workers": "2"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ResolveParams() = %v, want %v", got, want)
+	}
+}
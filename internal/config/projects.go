@@ -0,0 +1,150 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/killallgit/dick/internal/common"
+)
+
+// projectsFileName is the per-user index of projects with known environments
+const projectsFileName = "projects.json"
+
+// projectIndex lists the projects the reaper should watch
+type projectIndex struct {
+	Version  int      `json:"version"`
+	Projects []string `json:"projects"`
+}
+
+// UserStateDir returns the per-user directory for state shared across projects,
+// honouring XDG_STATE_HOME and defaulting to ~/.local/state/dick
+func UserStateDir() (string, error) {
+	if dir := os.Getenv("XDG_STATE_HOME"); dir != "" {
+		return filepath.Join(dir, "dick"), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+
+	return filepath.Join(home, ".local", "state", "dick"), nil
+}
+
+// KnownProjects returns the projects that have registered environments
+func KnownProjects() ([]string, error) {
+	dir, err := UserStateDir()
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := readProjectIndex(dir)
+	if err != nil {
+		return nil, err
+	}
+	return index.Projects, nil
+}
+
+// RegisterProject adds a project to the per-user index
+func RegisterProject(projectPath string) error {
+	return updateProjectIndex(func(index *projectIndex) {
+		for _, p := range index.Projects {
+			if p == projectPath {
+				return
+			}
+		}
+		index.Projects = append(index.Projects, projectPath)
+		sort.Strings(index.Projects)
+	})
+}
+
+// UnregisterProject removes a project from the per-user index
+func UnregisterProject(projectPath string) error {
+	return updateProjectIndex(func(index *projectIndex) {
+		kept := index.Projects[:0]
+		for _, p := range index.Projects {
+			if p != projectPath {
+				kept = append(kept, p)
+			}
+		}
+		index.Projects = kept
+	})
+}
+
+// UnregisterIdleProject removes a project from the per-user index if none of
+// its environments holds resources. The state is
+// checked under its lock, which UpdateEnvironment releases before it
+// registers the project, so a project registered meanwhile is never dropped.
+func UnregisterIdleProject(projectPath string) error {
+	lock, err := LockFile(filepath.Join(StateDir(projectPath), stateLockName))
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	state, err := ReadState(projectPath)
+	if err != nil {
+		return err
+	}
+	for _, env := range state.Environments {
+		if env.HoldsResources() {
+			return nil
+		}
+	}
+	return UnregisterProject(projectPath)
+}
+
+// updateProjectIndex applies fn to the project index under its lock
+func updateProjectIndex(fn func(index *projectIndex)) error {
+	dir, err := UserStateDir()
+	if err != nil {
+		return err
+	}
+
+	lock, err := LockFile(filepath.Join(dir, projectsFileName+".lock"))
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	index, err := readProjectIndex(dir)
+	if err != nil {
+		return err
+	}
+
+	fn(index)
+
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode project index: %w", err)
+	}
+
+	if err := common.WriteFileAtomic(filepath.Join(dir, projectsFileName), append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write project index: %w", err)
+	}
+
+	return nil
+}
+
+// readProjectIndex reads the project index, treating a missing file as empty
+func readProjectIndex(dir string) (*projectIndex, error) {
+	index := &projectIndex{Version: 1}
+
+	data, err := os.ReadFile(filepath.Join(dir, projectsFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return index, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read project index: %w", err)
+	}
+
+	if err := json.Unmarshal(data, index); err != nil {
+		return nil, fmt.Errorf("failed to parse project index: %w", err)
+	}
+
+	return index, nil
+}
@@ -0,0 +1,208 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/killallgit/dick/internal/common"
+)
+
+const (
+	// StateDirName is the per-project directory holding runtime state
+	StateDirName = ".dick"
+
+	// stateFileName is the state file inside the state directory
+	stateFileName = "state.json"
+
+	// stateLockName guards read-modify-write cycles on the state file
+	stateLockName = "state.lock"
+
+	// stateVersion is the current state file schema version
+	stateVersion = 1
+)
+
+// State is the runtime lifecycle state of a project. It lives apart from
+// .dick.yaml so the user configuration stays purely declarative.
+type State struct {
+	Version      int                     `json:"version"`
+	Environments map[string]*Environment `json:"environments"`
+}
+
+// StateDir returns the state directory for a project
+func StateDir(projectPath string) string {
+	return filepath.Join(projectPath, StateDirName)
+}
+
+// LogPath returns the log of an operation on an environment, e.g. create
+func LogPath(projectPath, name, operation string) string {
+	return filepath.Join(StateDir(projectPath), "logs", name, operation+".log")
+}
+
+// StatePath returns the state file path for a project
+func StatePath(projectPath string) string {
+	return filepath.Join(StateDir(projectPath), stateFileName)
+}
+
+// StateExists reports whether a project already has a state file
+func StateExists(projectPath string) bool {
+	_, err := os.Stat(StatePath(projectPath))
+	return err == nil
+}
+
+// ReadState reads the state of a project. A missing state file yields an empty state.
+func ReadState(projectPath string) (*State, error) {
+	state := &State{
+		Version:      stateVersion,
+		Environments: make(map[string]*Environment),
+	}
+
+	data, err := os.ReadFile(StatePath(projectPath))
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to parse state file %s: %w", StatePath(projectPath), err)
+	}
+	if state.Version > stateVersion {
+		return nil, fmt.Errorf("state file %s has version %d, this dick understands up to %d",
+			StatePath(projectPath), state.Version, stateVersion)
+	}
+	if state.Environments == nil {
+		state.Environments = make(map[string]*Environment)
+	}
+
+	return state, nil
+}
+
+// WriteState atomically replaces the state of a project, creating the state
+// directory if needed. Callers doing read-modify-write should use UpdateState.
+func WriteState(projectPath string, state *State) error {
+	if err := ensureStateDir(projectPath); err != nil {
+		return err
+	}
+
+	state.Version = stateVersion
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode state: %w", err)
+	}
+
+	if err := common.WriteFileAtomic(StatePath(projectPath), append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateState runs fn against the current state of a project while holding
+// the state lock, and writes the result back if fn succeeds
+func UpdateState(projectPath string, fn func(state *State) error) error {
+	lock, err := LockFile(filepath.Join(StateDir(projectPath), stateLockName))
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	state, err := ReadState(projectPath)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(state); err != nil {
+		return err
+	}
+
+	return WriteState(projectPath, state)
+}
+
+// ReadEnvironment reads the latest persisted record of an environment.
+// It returns nil without error if the environment is unknown.
+func ReadEnvironment(projectPath, name string) (*Environment, error) {
+	state, err := ReadState(projectPath)
+	if err != nil {
+		return nil, err
+	}
+	return state.Environments[name], nil
+}
+
+// UpdateEnvironment applies fn to the latest persisted record of an
+// environment and saves the result while holding the state lock, so changes
+// other processes made since the caller read the record are kept. An unknown
+// environment is passed to fn as an empty record. The state is left untouched
+// if fn fails. It returns the updated record.
+func UpdateEnvironment(projectPath, name string, fn func(env *Environment) error) (*Environment, error) {
+	if projectPath == "" {
+		pwd, err := os.Getwd()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get current directory: %w", err)
+		}
+		projectPath = pwd
+	}
+
+	var updated *Environment
+	if err := UpdateState(projectPath, func(state *State) error {
+		env := state.Environments[name]
+		if env == nil {
+			env = &Environment{Name: name}
+		}
+
+		// Work on a copy, a failing fn must not leave half a change behind
+		current := *env
+		if err := fn(&current); err != nil {
+			return err
+		}
+		current.Name = name
+		current.ProjectPath = projectPath
+
+		state.Environments[name] = &current
+		updated = &current
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	// Make sure the reaper knows where to find active environments
+	if updated.HoldsResources() {
+		if err := RegisterProject(projectPath); err != nil {
+			return nil, fmt.Errorf("failed to register project: %w", err)
+		}
+	}
+
+	return updated, nil
+}
+
+// Update applies fn to the latest persisted record of the environment with
+// UpdateEnvironment, and replaces e with the result
+func (e *Environment) Update(fn func(env *Environment) error) error {
+	updated, err := UpdateEnvironment(e.ProjectPath, e.Name, fn)
+	if err != nil {
+		return err
+	}
+	*e = *updated
+	return nil
+}
+
+// ensureStateDir creates the state directory with a .gitignore so runtime
+// state is never committed alongside .dick.yaml
+func ensureStateDir(projectPath string) error {
+	dir := StateDir(projectPath)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	ignore := filepath.Join(dir, ".gitignore")
+	if _, err := os.Stat(ignore); errors.Is(err, os.ErrNotExist) {
+		if err := os.WriteFile(ignore, []byte("*\n"), 0o644); err != nil {
+			return fmt.Errorf("failed to write state .gitignore: %w", err)
+		}
+	}
+
+	return nil
+}
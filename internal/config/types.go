@@ -1,14 +1,22 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 )
 
+// ErrNoEnvironments is returned when no environment has been registered yet
+var ErrNoEnvironments = errors.New("no environments found (run 'dick new' to create one)")
+
 // GlobalConfig represents the global CLI configuration flags
 type GlobalConfig struct {
 	Verbose bool `mapstructure:"verbose" yaml:"verbose,omitempty"`
 	Silent  bool `mapstructure:"silent" yaml:"silent,omitempty"`
+	Yes     bool `mapstructure:"yes" yaml:"yes,omitempty"`
+	NoInput bool `mapstructure:"no_input" yaml:"no_input,omitempty"`
 }
 
 // NewConfig represents configuration for the 'new' command
@@ -17,42 +25,256 @@ type NewConfig struct {
 	Name     string `mapstructure:"name" yaml:"name,omitempty"`
 	Provider string `mapstructure:"provider" yaml:"provider,omitempty"`
 	Force    bool   `mapstructure:"force" yaml:"force,omitempty"`
+	Output   string `mapstructure:"output" yaml:"output,omitempty"`
+	Detach   bool   `mapstructure:"detach" yaml:"detach,omitempty"`
+
+	// OnCreateFailure is destroy to tear down whatever a failed or
+	// interrupted create left behind, or keep to leave it until its TTL
+	OnCreateFailure string `mapstructure:"on_create_failure" yaml:"on_create_failure,omitempty"`
+
+	// Set holds name=value parameter assignments and Values files of
+	// parameters, applied over the defaults declared in params
+	Set    []string `mapstructure:"set" yaml:"set,omitempty"`
+	Values []string `mapstructure:"values" yaml:"values,omitempty"`
 }
 
 // StatusConfig represents configuration for the 'status' command  
 type StatusConfig struct {
-	Watch bool `mapstructure:"watch" yaml:"watch,omitempty"`
+	Watch  bool   `mapstructure:"watch" yaml:"watch,omitempty"`
+	Name   string `mapstructure:"name" yaml:"name,omitempty"`
+	Output string `mapstructure:"output" yaml:"output,omitempty"`
+
+	// Reconcile checks the environments against their providers first
+	Reconcile bool `mapstructure:"reconcile" yaml:"reconcile,omitempty"`
 }
 
 // DestroyConfig represents configuration for the 'destroy' command
 type DestroyConfig struct {
-	Force bool `mapstructure:"force" yaml:"force,omitempty"`
+	Force  bool   `mapstructure:"force" yaml:"force,omitempty"`
+	Name   string `mapstructure:"name" yaml:"name,omitempty"`
+	Output string `mapstructure:"output" yaml:"output,omitempty"`
+}
+
+// ExtendConfig represents configuration for the 'extend' command
+type ExtendConfig struct {
+	By     string `mapstructure:"by" yaml:"by,omitempty"`
+	Until  string `mapstructure:"until" yaml:"until,omitempty"`
+	Name   string `mapstructure:"name" yaml:"name,omitempty"`
+	Output string `mapstructure:"output" yaml:"output,omitempty"`
+}
+
+// RunConfig represents configuration for the 'run' command. Its provider,
+// TTL and name flags share the 'new' settings.
+type RunConfig struct {
+	KeepOnFailure bool `mapstructure:"keep_on_failure" yaml:"keep_on_failure,omitempty"`
+}
+
+// ReconcileConfig represents configuration for the 'reconcile' command
+type ReconcileConfig struct {
+	Output string `mapstructure:"output" yaml:"output,omitempty"`
+}
+
+// OutputConfig represents configuration for the 'output' command
+type OutputConfig struct {
+	Name string `mapstructure:"name" yaml:"name,omitempty"`
+}
+
+// HooksCmdConfig represents configuration for the 'hooks' commands
+type HooksCmdConfig struct {
+	Provider string `mapstructure:"provider" yaml:"provider,omitempty"`
+	Force    bool   `mapstructure:"force" yaml:"force,omitempty"`
+}
+
+// GCConfig represents configuration for the 'gc' command
+type GCConfig struct {
+	DryRun bool `mapstructure:"dry_run" yaml:"dry_run,omitempty"`
+	Force  bool `mapstructure:"force" yaml:"force,omitempty"`
+
+	// All includes resources of other users and resources without an owner
+	// that no environment of this user records
+	All bool `mapstructure:"all" yaml:"all,omitempty"`
+}
+
+// WaitConfig represents configuration for the 'wait' command
+type WaitConfig struct {
+	For      string `mapstructure:"for" yaml:"for,omitempty"`
+	Timeout  string `mapstructure:"timeout" yaml:"timeout,omitempty"`
+	Interval string `mapstructure:"interval" yaml:"interval,omitempty"`
+	Probe    bool   `mapstructure:"probe" yaml:"probe,omitempty"`
+}
+
+// KindConfig represents configuration for the kind provider
+type KindConfig struct {
+	// Taskfile opts into running the hooks of tasks/Taskfile.k8s.yaml
+	// instead of invoking kind directly
+	Taskfile bool `mapstructure:"taskfile" yaml:"taskfile,omitempty"`
+
+	// Config is a kind cluster config file relative to the project. It
+	// replaces the generated config, so the node settings below are ignored.
+	Config string `mapstructure:"config" yaml:"config,omitempty"`
+
+	// Image is the node image, e.g. kindest/node:v1.31.0
+	Image string `mapstructure:"image" yaml:"image,omitempty"`
+
+	// ControlPlanes and Workers are the number of nodes of each role
+	ControlPlanes int `mapstructure:"control_planes" yaml:"control_planes,omitempty"`
+	Workers       int `mapstructure:"workers" yaml:"workers,omitempty"`
+
+	// PortMappings are exposed on the first control plane node
+	PortMappings []KindPortMapping `mapstructure:"port_mappings" yaml:"port_mappings,omitempty"`
+
+	// Readiness decides when a new cluster is ready for use
+	Readiness ReadinessConfig `mapstructure:"readiness" yaml:"readiness,omitempty"`
+}
+
+// KindPortMapping maps a node container port to a host port
+type KindPortMapping struct {
+	ContainerPort int    `mapstructure:"container_port" yaml:"container_port"`
+	HostPort      int    `mapstructure:"host_port" yaml:"host_port"`
+	Protocol      string `mapstructure:"protocol" yaml:"protocol,omitempty"`
+	ListenAddress string `mapstructure:"listen_address" yaml:"listen_address,omitempty"`
+}
+
+// TofuConfig represents configuration for the OpenTofu provider
+type TofuConfig struct {
+	// Module is the directory of the root module, relative to the project
+	Module string `mapstructure:"module" yaml:"module,omitempty"`
+
+	// Binary is the tofu executable (terraform works too)
+	Binary string `mapstructure:"binary" yaml:"binary,omitempty"`
+
+	// Vars are passed to every plan, apply and destroy as -var values
+	Vars map[string]string `mapstructure:"vars" yaml:"vars,omitempty"`
+
+	// Readiness decides when a new environment is ready for use
+	Readiness ReadinessConfig `mapstructure:"readiness" yaml:"readiness,omitempty"`
+}
+
+// ReadinessConfig configures the probes a new environment has to pass before
+// it becomes active. Every configured probe has to pass.
+type ReadinessConfig struct {
+	// Command is run by the shell in the project directory and passes when
+	// it exits with status 0
+	Command string `mapstructure:"command" yaml:"command,omitempty"`
+
+	// HTTP is a URL that passes when a GET answers with a 2xx or 3xx status
+	HTTP string `mapstructure:"http" yaml:"http,omitempty"`
+
+	// Nodes waits for every node to report Ready (kind only)
+	Nodes bool `mapstructure:"nodes" yaml:"nodes,omitempty"`
+
+	// Timeout is how long the probes may take to pass, Interval how often
+	// they are retried
+	Timeout  string `mapstructure:"timeout" yaml:"timeout,omitempty"`
+	Interval string `mapstructure:"interval" yaml:"interval,omitempty"`
+
+	// OnFailure is destroy to tear the environment down when the probes
+	// don't pass in time, or keep to leave it for debugging until its TTL
+	OnFailure string `mapstructure:"on_failure" yaml:"on_failure,omitempty"`
+}
+
+// HooksConfig configures the optional lifecycle hooks of Taskfile-driven
+// providers, the hook:<name> tasks next to hook:setup and hook:teardown
+type HooksConfig struct {
+	PreCreate     HookConfig          `mapstructure:"pre_create" yaml:"pre_create,omitempty"`
+	PostCreate    HookConfig          `mapstructure:"post_create" yaml:"post_create,omitempty"`
+	Healthcheck   HookConfig          `mapstructure:"healthcheck" yaml:"healthcheck,omitempty"`
+	PreDestroy    HookConfig          `mapstructure:"pre_destroy" yaml:"pre_destroy,omitempty"`
+	PostDestroy   HookConfig          `mapstructure:"post_destroy" yaml:"post_destroy,omitempty"`
+	ExpiryWarning ExpiryWarningConfig `mapstructure:"expiry_warning" yaml:"expiry_warning,omitempty"`
+}
+
+// HookConfig configures a single lifecycle hook
+type HookConfig struct {
+	// Timeout is how long the hook may run
+	Timeout string `mapstructure:"timeout" yaml:"timeout,omitempty"`
+
+	// OnFailure is abort to stop the operation when the hook fails, warn to
+	// report the failure and carry on, or ignore. Post-destroy and
+	// expiry-warning hooks can't abort.
+	OnFailure string `mapstructure:"on_failure" yaml:"on_failure,omitempty"`
+}
+
+// ExpiryWarningConfig configures the hook run shortly before an environment
+// expires
+type ExpiryWarningConfig struct {
+	HookConfig `mapstructure:",squash" yaml:",inline"`
+
+	// Before is how long before the expiry the hook runs
+	Before string `mapstructure:"before" yaml:"before,omitempty"`
+}
+
+// CleanupConfig represents configuration for out-of-process cleanup
+type CleanupConfig struct {
+	// Scheduler is the OS job scheduler used as a fallback to the reaper:
+	// auto, none, at, systemd, cron or schtasks
+	Scheduler string `mapstructure:"scheduler" yaml:"scheduler,omitempty"`
+}
+
+// PromptsConfig holds the answers to prompts when nobody can be asked,
+// i.e. with --no-input or without a terminal
+type PromptsConfig struct {
+	// DestroyExpired destroys expired clusters found by any command
+	DestroyExpired bool `mapstructure:"destroy_expired" yaml:"destroy_expired"`
+
+	// Destroy lets 'dick destroy' proceed without --force or --yes
+	Destroy bool `mapstructure:"destroy" yaml:"destroy"`
+
+	// ResumeCreate finishes an interrupted 'dick new' instead of rolling it
+	// back, ResumeDestroy finishes an interrupted teardown instead of
+	// keeping the environment
+	ResumeCreate  bool `mapstructure:"resume_create" yaml:"resume_create"`
+	ResumeDestroy bool `mapstructure:"resume_destroy" yaml:"resume_destroy"`
+
+	// GC lets 'dick gc' delete orphaned resources without --force or --yes
+	GC bool `mapstructure:"gc" yaml:"gc"`
 }
 
 // Config represents the complete application configuration with proper namespacing
 type Config struct {
 	// Command-specific configurations with proper namespacing
-	Global     GlobalConfig  `mapstructure:"global" yaml:"global,omitempty"`
-	New        NewConfig     `mapstructure:"new" yaml:"new,omitempty"`
-	StatusCmd  StatusConfig  `mapstructure:"status_cmd" yaml:"status_cmd,omitempty"`
-	Destroy    DestroyConfig `mapstructure:"destroy" yaml:"destroy,omitempty"`
+	Global    GlobalConfig    `mapstructure:"global" yaml:"global,omitempty"`
+	New       NewConfig       `mapstructure:"new" yaml:"new,omitempty"`
+	StatusCmd StatusConfig    `mapstructure:"status_cmd" yaml:"status_cmd,omitempty"`
+	Destroy   DestroyConfig   `mapstructure:"destroy" yaml:"destroy,omitempty"`
+	Extend    ExtendConfig    `mapstructure:"extend" yaml:"extend,omitempty"`
+	Run       RunConfig       `mapstructure:"run" yaml:"run,omitempty"`
+	Wait      WaitConfig      `mapstructure:"wait" yaml:"wait,omitempty"`
+	Reconcile ReconcileConfig `mapstructure:"reconcile" yaml:"reconcile,omitempty"`
+	GC        GCConfig        `mapstructure:"gc" yaml:"gc,omitempty"`
+	OutputCmd OutputConfig    `mapstructure:"output_cmd" yaml:"output_cmd,omitempty"`
+	HooksCmd  HooksCmdConfig  `mapstructure:"hooks_cmd" yaml:"hooks_cmd,omitempty"`
+	Cleanup   CleanupConfig   `mapstructure:"cleanup" yaml:"cleanup,omitempty"`
+	Prompts   PromptsConfig   `mapstructure:"prompts" yaml:"prompts,omitempty"`
+	Hooks     HooksConfig     `mapstructure:"hooks" yaml:"hooks,omitempty"`
+	Kind      KindConfig      `mapstructure:"kind" yaml:"kind,omitempty"`
+	Tofu      TofuConfig      `mapstructure:"tofu" yaml:"tofu,omitempty"`
 
-	// Legacy fields for backward compatibility and state tracking
+	// Params declares the parameters of the project's environments
+	Params map[string]ParamConfig `mapstructure:"params" yaml:"params,omitempty"`
+
+	// Legacy fields for backward compatibility
 	// These will be populated from new.* fields when needed
 	Provider string `mapstructure:"provider" yaml:"provider"`
 	TTL      string `mapstructure:"ttl" yaml:"ttl"`
 	Name     string `mapstructure:"name" yaml:"name"`
 	Force    bool   `mapstructure:"force" yaml:"force,omitempty"`
 
-	// State fields (unchanged)
-	Status           string    `mapstructure:"status" yaml:"status,omitempty"`
-	CreatedAt        time.Time `mapstructure:"created_at" yaml:"created_at,omitempty"`
-	ExpiresAt        time.Time `mapstructure:"expires_at" yaml:"expires_at,omitempty"`
-	ProjectPath      string    `mapstructure:"project_path" yaml:"project_path,omitempty"`
-	CleanupAttempted bool      `mapstructure:"cleanup_attempted" yaml:"cleanup_attempted,omitempty"`
-	ScheduledJobID   string    `mapstructure:"scheduled_job_id" yaml:"scheduled_job_id,omitempty"`
-	
-	// Enhanced cleanup tracking
+	// Environment registry keyed by environment name, merged in from the
+	// state store by LoadConfig and never written to .dick.yaml
+	Environments map[string]*Environment `mapstructure:"-" yaml:"-"`
+
+	// configFile is the absolute path of the file the config was read from
+	configFile string
+
+	// Deprecated single-environment state, read only to migrate older
+	// .dick.yaml files into the environment registry
+	Status             string    `mapstructure:"status" yaml:"status,omitempty"`
+	CreatedAt          time.Time `mapstructure:"created_at" yaml:"created_at,omitempty"`
+	ExpiresAt          time.Time `mapstructure:"expires_at" yaml:"expires_at,omitempty"`
+	ProjectPath        string    `mapstructure:"project_path" yaml:"project_path,omitempty"`
+	CleanupAttempted   bool      `mapstructure:"cleanup_attempted" yaml:"cleanup_attempted,omitempty"`
+	ScheduledJobID     string    `mapstructure:"scheduled_job_id" yaml:"scheduled_job_id,omitempty"`
 	LastCleanupAttempt time.Time `mapstructure:"last_cleanup_attempt" yaml:"last_cleanup_attempt,omitempty"`
 	CleanupAttempts    int       `mapstructure:"cleanup_attempts" yaml:"cleanup_attempts,omitempty"`
 	LastCleanupError   string    `mapstructure:"last_cleanup_error" yaml:"last_cleanup_error,omitempty"`
@@ -67,167 +289,122 @@ func (c *Config) ParseTTL() (time.Duration, error) {
 	return duration, nil
 }
 
-// SetActive marks the cluster as active with proper timestamps
-func (c *Config) SetActive() error {
-	duration, err := c.ParseTTL()
-	if err != nil {
-		return err
+// NewEnvironment returns a fresh environment record built from the
+// current provider, TTL and name settings. It is not added to the registry.
+func (c *Config) NewEnvironment() *Environment {
+	return &Environment{
+		ID:          newEnvironmentID(),
+		Name:        c.Name,
+		Provider:    c.Provider,
+		TTL:         c.TTL,
+		ProjectPath: c.ProjectPath,
+		ConfigFile:  c.configFile,
 	}
-
-	c.Status = "active"
-	c.CreatedAt = time.Now()
-	c.ExpiresAt = c.CreatedAt.Add(duration)
-	c.CleanupAttempted = false
-	
-	return nil
 }
 
-// SetDestroyed marks the cluster as destroyed
-func (c *Config) SetDestroyed() {
-	c.Status = "destroyed"
-	c.CleanupAttempted = true
-	c.ScheduledJobID = ""
-}
-
-// IsExpired returns true if the cluster has expired
-func (c *Config) IsExpired() bool {
-	return c.Status == "active" && time.Now().After(c.ExpiresAt)
-}
-
-// TimeRemaining returns the time until expiration
-func (c *Config) TimeRemaining() time.Duration {
-	if c.Status != "active" {
-		return 0
+// Environment returns the named environment or nil if it is not registered
+func (c *Config) Environment(name string) *Environment {
+	if c.Environments == nil {
+		return nil
 	}
-	remaining := c.ExpiresAt.Sub(time.Now())
-	if remaining < 0 {
-		return 0
-	}
-	return remaining
+	return c.Environments[name]
 }
 
-// CheckExpiration returns if cluster is expired and how long it's been expired
-func (c *Config) CheckExpiration() (expired bool, expiredSince time.Duration) {
-	if c.Status != "active" {
-		return false, 0
+// PutEnvironment adds or replaces an environment in the registry
+func (c *Config) PutEnvironment(env *Environment) {
+	if c.Environments == nil {
+		c.Environments = make(map[string]*Environment)
 	}
-	
-	now := time.Now()
-	if now.After(c.ExpiresAt) {
-		return true, now.Sub(c.ExpiresAt)
+	if env.ProjectPath == "" {
+		env.ProjectPath = c.ProjectPath
 	}
-	
-	return false, 0
+	c.Environments[env.Name] = env
 }
 
-// ShouldPromptDestroy determines if user should be prompted for cleanup
-func (c *Config) ShouldPromptDestroy() bool {
-	expired, _ := c.CheckExpiration()
-	
-	// Don't prompt if not expired, already destroyed, or cleanup attempted
-	if !expired || c.Status != "active" || c.CleanupAttempted {
-		return false
+// ListEnvironments returns all registered environments, most recently created first
+func (c *Config) ListEnvironments() []*Environment {
+	envs := make([]*Environment, 0, len(c.Environments))
+	for _, env := range c.Environments {
+		envs = append(envs, env)
 	}
-	
-	// Don't prompt if force mode is enabled
-	if c.Force {
-		return false
-	}
-	
-	// Always prompt for manual cleanup when not in force mode
-	return true
-}
-
-// ShouldAutoDestroy determines if automatic cleanup should happen
-func (c *Config) ShouldAutoDestroy() bool {
-	expired, _ := c.CheckExpiration()
-	
-	// Only auto-destroy if expired, active, and force mode enabled
-	return expired && c.Status == "active" && c.Force && !c.CleanupAttempted
-}
-
-// ShouldAttemptCleanup determines if we should attempt any form of cleanup
-func (c *Config) ShouldAttemptCleanup() bool {
-	expired, _ := c.CheckExpiration()
-	
-	// Attempt cleanup if expired, active, and not already attempted
-	return expired && c.Status == "active" && !c.CleanupAttempted
-}
-
-// MarkCleanupAttempted marks that cleanup has been attempted
-func (c *Config) MarkCleanupAttempted() {
-	c.CleanupAttempted = true
-	c.LastCleanupAttempt = time.Now()
-	c.CleanupAttempts++
+	sort.Slice(envs, func(i, j int) bool {
+		if !envs[i].CreatedAt.Equal(envs[j].CreatedAt) {
+			return envs[i].CreatedAt.After(envs[j].CreatedAt)
+		}
+		return envs[i].Name < envs[j].Name
+	})
+	return envs
 }
 
-// MarkCleanupFailed records a failed cleanup attempt with error details
-func (c *Config) MarkCleanupFailed(err error) {
-	c.MarkCleanupAttempted()
-	if err != nil {
-		c.LastCleanupError = err.Error()
+// ActiveEnvironments returns all active environments, most recently created first
+func (c *Config) ActiveEnvironments() []*Environment {
+	var active []*Environment
+	for _, env := range c.ListEnvironments() {
+		if env.IsActive() {
+			active = append(active, env)
+		}
 	}
+	return active
 }
 
-// MarkCleanupSuccessful records a successful cleanup attempt
-func (c *Config) MarkCleanupSuccessful() {
-	c.MarkCleanupAttempted()
-	c.LastCleanupError = ""
-}
-
-// ShouldRetryCleanup determines if cleanup should be retried based on retry policy
-func (c *Config) ShouldRetryCleanup() bool {
-	expired, _ := c.CheckExpiration()
-	
-	// Don't retry if not expired or not active
-	if !expired || c.Status != "active" {
-		return false
+// ResolveEnvironment looks up an environment by name. With an empty name it
+// falls back to the only registered environment, or the most recent active
+// one (then the most recent overall) when several exist.
+func (c *Config) ResolveEnvironment(name string) (*Environment, error) {
+	if name != "" {
+		env := c.Environment(name)
+		if env == nil {
+			return nil, fmt.Errorf("environment '%s' not found (known: %s)", name, c.environmentNamesText())
+		}
+		return env, nil
 	}
-	
-	// Don't retry if we've exceeded max attempts
-	const maxCleanupAttempts = 3
-	if c.CleanupAttempts >= maxCleanupAttempts {
-		return false
+
+	envs := c.ListEnvironments()
+	if len(envs) == 0 {
+		return nil, ErrNoEnvironments
 	}
-	
-	// Don't retry if last attempt was too recent (exponential backoff)
-	if !c.LastCleanupAttempt.IsZero() {
-		timeSinceLastAttempt := time.Since(c.LastCleanupAttempt)
-		minRetryInterval := time.Duration(c.CleanupAttempts+1) * 5 * time.Minute
-		
-		if timeSinceLastAttempt < minRetryInterval {
-			return false
-		}
+	if active := c.ActiveEnvironments(); len(active) > 0 {
+		return active[0], nil
 	}
-	
-	return true
+	return envs[0], nil
 }
 
-// GetCleanupStatus returns a human-readable cleanup status
-func (c *Config) GetCleanupStatus() string {
-	if c.CleanupAttempts == 0 {
-		return "No cleanup attempts"
-	}
-	
-	status := fmt.Sprintf("%d attempt(s)", c.CleanupAttempts)
-	if !c.LastCleanupAttempt.IsZero() {
-		status += fmt.Sprintf(", last: %s", c.LastCleanupAttempt.Format("2006-01-02 15:04:05"))
+// environmentNamesText returns the registered names for error messages
+func (c *Config) environmentNamesText() string {
+	if len(c.Environments) == 0 {
+		return "none"
 	}
-	if c.LastCleanupError != "" {
-		status += fmt.Sprintf(", error: %s", c.LastCleanupError)
+	names := make([]string, 0, len(c.Environments))
+	for name := range c.Environments {
+		names = append(names, name)
 	}
-	
-	return status
+	sort.Strings(names)
+	return strings.Join(names, ", ")
 }
 
-// SetScheduledJobID stores the OS scheduler job ID
-func (c *Config) SetScheduledJobID(jobID string) {
-	c.ScheduledJobID = jobID
-}
+// migrateLegacyState moves the deprecated top-level state fields into the
+// environment registry so older single-environment configs keep working.
+// It reports whether anything was migrated.
+func (c *Config) migrateLegacyState() bool {
+	if c.Status == "" || c.Name == "" || c.Environment(c.Name) != nil {
+		return false
+	}
 
-// ClearScheduledJob removes the scheduled job ID
-func (c *Config) ClearScheduledJob() {
-	c.ScheduledJobID = ""
+	c.PutEnvironment(&Environment{
+		Name:               c.Name,
+		Provider:           c.Provider,
+		TTL:                c.TTL,
+		ProjectPath:        c.ProjectPath,
+		Status:             c.Status,
+		CreatedAt:          c.CreatedAt,
+		ExpiresAt:          c.ExpiresAt,
+		CleanupAttempted:   c.CleanupAttempted,
+		ScheduledJobID:     c.ScheduledJobID,
+		LastCleanupAttempt: c.LastCleanupAttempt,
+		CleanupAttempts:    c.CleanupAttempts,
+		LastCleanupError:   c.LastCleanupError,
+	})
+	return true
 }
 
 // SyncLegacyFields synchronizes between namespaced and legacy fields
@@ -279,6 +456,47 @@ func (c *Config) GetEffectiveDestroyConfig() DestroyConfig {
 	return c.Destroy
 }
 
+// GetEffectiveExtendConfig returns the effective extend command configuration
+func (c *Config) GetEffectiveExtendConfig() ExtendConfig {
+	return c.Extend
+}
+
+// GetEffectiveRunConfig returns the effective run command configuration
+func (c *Config) GetEffectiveRunConfig() RunConfig {
+	return c.Run
+}
+
+// GetEffectiveWaitConfig returns the effective wait command configuration
+func (c *Config) GetEffectiveWaitConfig() WaitConfig {
+	return c.Wait
+}
+
+// GetEffectiveReconcileConfig returns the effective reconcile command configuration
+func (c *Config) GetEffectiveReconcileConfig() ReconcileConfig {
+	return c.Reconcile
+}
+
+// GetEffectiveGCConfig returns the effective gc command configuration
+func (c *Config) GetEffectiveGCConfig() GCConfig {
+	return c.GC
+}
+
+// GetEffectiveOutputConfig returns the effective output command configuration
+func (c *Config) GetEffectiveOutputConfig() OutputConfig {
+	return c.OutputCmd
+}
+
+// GetEffectiveHooksConfig returns the effective hooks command configuration,
+// falling back to the configured provider
+func (c *Config) GetEffectiveHooksConfig() HooksCmdConfig {
+	c.SyncLegacyFields()
+	hooks := c.HooksCmd
+	if hooks.Provider == "" {
+		hooks.Provider = c.Provider
+	}
+	return hooks
+}
+
 // GetEffectiveGlobalConfig returns the effective global configuration
 func (c *Config) GetEffectiveGlobalConfig() GlobalConfig {
 	return c.Global
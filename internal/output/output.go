@@ -0,0 +1,174 @@
+// Package output renders environments in the machine readable formats of --output.
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/killallgit/dick/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+// SchemaVersion is the version of the document layout. It is bumped whenever
+// a field is renamed or removed; new fields may be added within a version.
+const SchemaVersion = 1
+
+// Format is an output format of --output
+type Format string
+
+const (
+	// Text is the human readable output
+	Text Format = "text"
+	// JSON is a JSON document
+	JSON Format = "json"
+	// YAML is a YAML document
+	YAML Format = "yaml"
+)
+
+// Formats lists the supported output formats
+var Formats = []Format{Text, JSON, YAML}
+
+// ParseFormat parses an --output value. Empty means text.
+func ParseFormat(s string) (Format, error) {
+	if s == "" {
+		return Text, nil
+	}
+	for _, f := range Formats {
+		if Format(strings.ToLower(s)) == f {
+			return f, nil
+		}
+	}
+
+	names := make([]string, len(Formats))
+	for i, f := range Formats {
+		names[i] = string(f)
+	}
+	return "", fmt.Errorf("unsupported output format '%s' (supported: %s)", s, strings.Join(names, ", "))
+}
+
+// Structured reports whether the format is machine readable
+func (f Format) Structured() bool {
+	return f == JSON || f == YAML
+}
+
+// Document is the top level object written by every command
+type Document struct {
+	SchemaVersion int           `json:"schema_version" yaml:"schema_version"`
+	Environments  []Environment `json:"environments" yaml:"environments"`
+}
+
+// Environment is the machine readable view of an environment. Timestamps are
+// RFC 3339 in UTC and null when unset.
+type Environment struct {
+	ID          string     `json:"id" yaml:"id"`
+	Name        string     `json:"name" yaml:"name"`
+	Provider    string     `json:"provider" yaml:"provider"`
+	Status      string     `json:"status" yaml:"status"`
+	TTL         string     `json:"ttl" yaml:"ttl"`
+	ProjectPath string     `json:"project_path" yaml:"project_path"`
+	CreatedAt   *time.Time `json:"created_at" yaml:"created_at"`
+	ExpiresAt   *time.Time `json:"expires_at" yaml:"expires_at"`
+
+	// RemainingSeconds is the time left before expiry of an active
+	// environment, negative once it is overdue and 0 otherwise
+	RemainingSeconds int64 `json:"remaining_seconds" yaml:"remaining_seconds"`
+	Expired          bool  `json:"expired" yaml:"expired"`
+
+	CleanupAttempts  int    `json:"cleanup_attempts" yaml:"cleanup_attempts"`
+	LastCleanupError string `json:"last_cleanup_error" yaml:"last_cleanup_error"`
+
+	// FailureReason says why the environment failed, e.g. it never became ready
+	FailureReason string `json:"failure_reason" yaml:"failure_reason"`
+
+	// Drift is the last difference found between the record and the
+	// infrastructure by 'dick reconcile'
+	Drift string `json:"drift" yaml:"drift"`
+
+	// Params are the resolved parameters the environment was created with
+	Params  map[string]string `json:"params" yaml:"params"`
+	Outputs map[string]string `json:"outputs" yaml:"outputs"`
+}
+
+// FromEnvironment converts an environment record as of now
+func FromEnvironment(env *config.Environment, now time.Time) Environment {
+	out := Environment{
+		ID:               env.ID,
+		Name:             env.Name,
+		Provider:         env.Provider,
+		Status:           env.Status,
+		TTL:              env.TTL,
+		ProjectPath:      env.ProjectPath,
+		CreatedAt:        timestamp(env.CreatedAt),
+		ExpiresAt:        timestamp(env.ExpiresAt),
+		CleanupAttempts:  env.CleanupAttempts,
+		LastCleanupError: env.LastCleanupError,
+		FailureReason:    env.FailureReason,
+		Drift:            env.Drift,
+		Params:           env.Params,
+		Outputs:          env.Outputs,
+	}
+	if out.Params == nil {
+		out.Params = map[string]string{}
+	}
+	if out.Outputs == nil {
+		out.Outputs = map[string]string{}
+	}
+
+	if env.HoldsResources() && !env.ExpiresAt.IsZero() {
+		remaining := env.ExpiresAt.Sub(now)
+		out.RemainingSeconds = int64(remaining / time.Second)
+		out.Expired = remaining <= 0
+	}
+
+	return out
+}
+
+// NewDocument builds the document for a list of environments, sorted by name
+func NewDocument(envs []*config.Environment) Document {
+	now := time.Now()
+	doc := Document{
+		SchemaVersion: SchemaVersion,
+		Environments:  make([]Environment, 0, len(envs)),
+	}
+	for _, env := range envs {
+		doc.Environments = append(doc.Environments, FromEnvironment(env, now))
+	}
+	sort.Slice(doc.Environments, func(i, j int) bool {
+		return doc.Environments[i].Name < doc.Environments[j].Name
+	})
+	return doc
+}
+
+// Write writes the document for envs to w in a structured format
+func Write(w io.Writer, format Format, envs []*config.Environment) error {
+	doc := NewDocument(envs)
+
+	switch format {
+	case JSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(doc)
+	case YAML:
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(doc); err != nil {
+			return err
+		}
+		return enc.Close()
+	default:
+		return fmt.Errorf("output format '%s' is not structured", format)
+	}
+}
+
+// timestamp returns t in UTC to the second, or nil if unset
+func timestamp(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	t = t.UTC().Truncate(time.Second)
+	return &t
+}
@@ -0,0 +1,33 @@
+package provider
+
+import (
+	"path/filepath"
+
+	"github.com/killallgit/dick/internal/config"
+	"github.com/killallgit/dick/internal/templates"
+)
+
+// HookSource is implemented by providers that run hook files, e.g. the
+// Taskfile of kind or the root module of tofu. Projects without their own
+// run the built-in ones embedded in dick.
+type HookSource interface {
+	// HookFiles returns the hook files run for the environment and whether
+	// they are the built-in ones. No files means the provider runs none.
+	HookFiles(env *config.Environment) (files []string, builtin bool, err error)
+}
+
+// BuiltinHooksDir returns the directory the built-in hook files of a
+// provider are written to for a project, laid out as in the project
+func BuiltinHooksDir(projectDir, provider string) string {
+	return filepath.Join(config.StateDir(projectDir), "hooks", provider)
+}
+
+// builtinHookFile writes the built-in hook files of a provider and returns
+// the path of one of them, relative to the project layout
+func builtinHookFile(projectDir, provider, path string) (string, error) {
+	dir := BuiltinHooksDir(projectDir, provider)
+	if err := templates.Materialize(dir, provider); err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, filepath.FromSlash(path)), nil
+}
@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/killallgit/dick/internal/config"
+)
+
+// outputVarPattern matches the characters not allowed in variable names
+var outputVarPattern = regexp.MustCompile(`[^A-Z0-9_]`)
+
+// Environ returns the variables describing an environment to hooks and
+// commands run against it:
+//
+//	DICK_ENV_NAME, DICK_ENV_ID, DICK_PROVIDER  the environment
+//	DICK_TTL, DICK_EXPIRES_AT                  its TTL and expiry (RFC 3339)
+//	DICK_PROJECT_PATH                          the project directory
+//	DICK_LABEL_FILE                            labels for docker resources
+//	DICK_OUTPUTS_DIR, DICK_OUTPUTS_FILE        where hooks put generated files
+//	                                           and key=value outputs
+//	DICK_PARAM_<NAME>                          each parameter of the environment
+//	DICK_OUTPUT_<KEY>                          each output of the environment
+//	KUBECONFIG                                 when an output provides one
+func Environ(env *config.Environment) []string {
+	vars := []string{
+		"DICK_ENV_NAME=" + env.Name,
+		"DICK_ENV_ID=" + env.ID,
+		"DICK_PROVIDER=" + env.Provider,
+		"DICK_TTL=" + env.TTL,
+		"DICK_PROJECT_PATH=" + env.ProjectPath,
+		"DICK_LABEL_FILE=" + LabelFile(env),
+		"DICK_OUTPUTS_DIR=" + OutputsDir(env),
+		"DICK_OUTPUTS_FILE=" + OutputsFile(env),
+	}
+	if !env.ExpiresAt.IsZero() {
+		vars = append(vars, "DICK_EXPIRES_AT="+env.ExpiresAt.UTC().Format(time.RFC3339))
+	}
+
+	vars = append(vars, prefixedVars("DICK_PARAM_", env.Params)...)
+	vars = append(vars, prefixedVars("DICK_OUTPUT_", env.Outputs)...)
+	if kubeconfig, ok := env.Outputs["kubeconfig"]; ok {
+		vars = append(vars, "KUBECONFIG="+kubeconfig)
+	}
+	return vars
+}
+
+// expandParams replaces ${name} in s with the value of the environment's
+// parameter; other references are kept as they are
+func expandParams(s string, env *config.Environment) string {
+	return os.Expand(s, func(name string) string {
+		if value, ok := env.Params[name]; ok {
+			return value
+		}
+		return "${" + name + "}"
+	})
+}
+
+// prefixedVars returns a variable for each value, named by the prefix and
+// its key in upper case, sorted by key
+func prefixedVars(prefix string, values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	vars := make([]string, 0, len(keys))
+	for _, key := range keys {
+		name := outputVarPattern.ReplaceAllString(strings.ToUpper(key), "_")
+		vars = append(vars, fmt.Sprintf("%s%s=%s", prefix, name, values[key]))
+	}
+	return vars
+}
@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/killallgit/dick/internal/common"
+)
+
+// commandWaitDelay is how long a tool stopped by its context may keep its
+// output open, e.g. through children that outlive it
+const commandWaitDelay = 5 * time.Second
+
+// outputLogKey is the context key of the writer receiving tool output
+type outputLogKey struct{}
+
+// WithOutputLog returns a context under which every provider tool also writes
+// its command line and output to w, e.g. to keep the output of a create
+func WithOutputLog(ctx context.Context, w io.Writer) context.Context {
+	return context.WithValue(ctx, outputLogKey{}, w)
+}
+
+// runCommand runs a provider tool. Output is streamed if requested, otherwise
+// it is captured, included in the error on failure and logged in verbose mode.
+// It is also copied to the output log of ctx, if any.
+func runCommand(ctx context.Context, cmd *exec.Cmd, stream bool) error {
+	cmd.WaitDelay = commandWaitDelay
+
+	return runWithOutput(ctx, cmd.Args, stream, func(stdout, stderr io.Writer) error {
+		cmd.Stdout = stdout
+		cmd.Stderr = stderr
+		return cmd.Run()
+	})
+}
+
+// runWithOutput runs a provider tool described by args, handing run the
+// writers for its output under the rules of runCommand
+func runWithOutput(ctx context.Context, args []string, stream bool, run func(stdout, stderr io.Writer) error) error {
+	outputLog, _ := ctx.Value(outputLogKey{}).(io.Writer)
+	if outputLog != nil {
+		fmt.Fprintf(outputLog, "$ %s\n", strings.Join(args, " "))
+	}
+
+	if stream {
+		if outputLog != nil {
+			return run(io.MultiWriter(os.Stdout, outputLog), io.MultiWriter(os.Stderr, outputLog))
+		}
+		return run(os.Stdout, os.Stderr)
+	}
+
+	var output bytes.Buffer
+	var w io.Writer = &output
+	if outputLog != nil {
+		w = io.MultiWriter(&output, outputLog)
+	}
+	// Tools writing from several goroutines share the buffer
+	w = &syncWriter{w: w}
+	if err := run(w, w); err != nil {
+		return fmt.Errorf("%w, output: %s", err, output.String())
+	}
+
+	if common.ShouldShowTaskOutput() {
+		log.Printf("%s output: %s", args[0], output.String())
+	}
+	return nil
+}
+
+// syncWriter serializes writes to w
+type syncWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (s *syncWriter) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.w.Write(p)
+}
@@ -0,0 +1,131 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/killallgit/dick/internal/config"
+)
+
+// Hook is a point in the lifecycle of an environment where optional hooks run
+type Hook string
+
+const (
+	// HookPreCreate runs before the environment is created
+	HookPreCreate Hook = "pre-create"
+
+	// HookHealthcheck runs once the environment passed its readiness probes
+	HookHealthcheck Hook = "healthcheck"
+
+	// HookPostCreate runs after the healthcheck, e.g. to seed data
+	HookPostCreate Hook = "post-create"
+
+	// HookPreDestroy runs before the environment is destroyed, e.g. to dump logs
+	HookPreDestroy Hook = "pre-destroy"
+
+	// HookPostDestroy runs after the environment was destroyed
+	HookPostDestroy Hook = "post-destroy"
+
+	// HookExpiryWarning runs shortly before the environment expires
+	HookExpiryWarning Hook = "expiry-warning"
+)
+
+// defaultHookTimeout bounds hooks that have no timeout configured
+const defaultHookTimeout = 10 * time.Minute
+
+// HookRunner is implemented by providers that run lifecycle hooks, e.g. the
+// hook:<name> tasks of a Taskfile
+type HookRunner interface {
+	// RunHook runs the hook of the environment. It reports false, without
+	// an error, when the environment has no such hook.
+	RunHook(ctx context.Context, env *config.Environment, hook Hook) (bool, error)
+}
+
+// HookError is a failed hook whose failure policy is abort or warn
+type HookError struct {
+	Hook Hook
+
+	// Abort is set when the operation running the hook has to stop
+	Abort bool
+
+	Err error
+}
+
+func (e *HookError) Error() string {
+	return fmt.Sprintf("%s hook failed: %v", e.Hook, e.Err)
+}
+
+func (e *HookError) Unwrap() error { return e.Err }
+
+// IsAbort reports whether err is a failed hook that aborts its operation
+func IsAbort(err error) bool {
+	var hookErr *HookError
+	return errors.As(err, &hookErr) && hookErr.Abort
+}
+
+// RunHook runs a lifecycle hook of the environment, if its provider has it,
+// under the timeout configured in the hooks section of .dick.yaml. Failures
+// of hooks with the ignore policy are dropped, the others are returned as a
+// *HookError. Hooks that run after the fact, post-destroy and expiry-warning,
+// never abort.
+func RunHook(ctx context.Context, p Provider, env *config.Environment, hook Hook) error {
+	runner, ok := p.(HookRunner)
+	if !ok {
+		return nil
+	}
+
+	settings, err := hookSettings(env, hook)
+	if err != nil {
+		return &HookError{Hook: hook, Err: err}
+	}
+
+	timeout := defaultHookTimeout
+	if settings.Timeout != "" {
+		if timeout, err = time.ParseDuration(settings.Timeout); err != nil {
+			return &HookError{Hook: hook, Err: fmt.Errorf("invalid timeout '%s': %w", settings.Timeout, err)}
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	_, err = runner.RunHook(ctx, env, hook)
+	if err == nil || settings.OnFailure == "ignore" {
+		return nil
+	}
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("timed out after %s: %w", timeout, err)
+	}
+
+	// Configuration validation rejects abort for hooks with nothing to
+	// abort; the reaper loads configuration without validating it
+	abort := settings.OnFailure == "abort" && hook != HookPostDestroy && hook != HookExpiryWarning
+	return &HookError{Hook: hook, Abort: abort, Err: err}
+}
+
+// hookSettings returns the settings of a hook from the environment's
+// configuration
+func hookSettings(env *config.Environment, hook Hook) (config.HookConfig, error) {
+	cfg, err := config.LoadEnvironmentConfig(env)
+	if err != nil {
+		return config.HookConfig{}, err
+	}
+
+	switch hook {
+	case HookPreCreate:
+		return cfg.Hooks.PreCreate, nil
+	case HookHealthcheck:
+		return cfg.Hooks.Healthcheck, nil
+	case HookPostCreate:
+		return cfg.Hooks.PostCreate, nil
+	case HookPreDestroy:
+		return cfg.Hooks.PreDestroy, nil
+	case HookPostDestroy:
+		return cfg.Hooks.PostDestroy, nil
+	case HookExpiryWarning:
+		return cfg.Hooks.ExpiryWarning.HookConfig, nil
+	}
+	return config.HookConfig{}, fmt.Errorf("unknown hook %s", hook)
+}
@@ -0,0 +1,358 @@
+package provider
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/killallgit/dick/internal/common"
+	"github.com/killallgit/dick/internal/config"
+)
+
+// kindProvider creates Kubernetes clusters by invoking kind directly. Projects
+// that set kind.taskfile keep using their tasks/Taskfile.k8s.yaml hooks.
+type kindProvider struct {
+	taskfile *taskfileProvider
+}
+
+func init() {
+	Register(&kindProvider{
+		taskfile: &taskfileProvider{
+			name:           "kind",
+			taskfile:       "Taskfile.k8s.yaml",
+			legacyTaskfile: "Taskfile.new.yaml",
+			legacySetup:    "kind:create",
+			legacyTeardown: "kind:destroy",
+			clusterName:    kindClusterName,
+		},
+	})
+}
+
+func (p *kindProvider) Name() string { return "kind" }
+
+func (p *kindProvider) Description() string {
+	return "Kubernetes in Docker via kind"
+}
+
+func (p *kindProvider) Validate(env *config.Environment) error {
+	settings, err := p.settings(env)
+	if err != nil {
+		return err
+	}
+	if settings.Taskfile {
+		return p.taskfile.Validate(env)
+	}
+
+	if _, err := exec.LookPath("kind"); err != nil {
+		return fmt.Errorf("kind command not found. Please install kind: https://kind.sigs.k8s.io/docs/user/quick-start/#installation")
+	}
+
+	if settings.Config != "" {
+		if _, err := os.Stat(p.configPath(env, settings)); err != nil {
+			return fmt.Errorf("kind config not found: %w", err)
+		}
+	} else if settings.ControlPlanes < 1 {
+		return fmt.Errorf("kind.control_planes must be at least 1")
+	} else if settings.Workers < 0 {
+		return fmt.Errorf("kind.workers must not be negative")
+	}
+
+	return nil
+}
+
+func (p *kindProvider) Create(ctx context.Context, env *config.Environment) error {
+	settings, err := p.settings(env)
+	if err != nil {
+		return err
+	}
+	if settings.Taskfile {
+		return p.taskfile.Create(ctx, env)
+	}
+
+	configPath := p.configPath(env, settings)
+	if settings.Config == "" {
+		if err := p.writeClusterConfig(env, settings); err != nil {
+			return err
+		}
+	}
+
+	args := []string{"create", "cluster", "--name", kindClusterName(env), "--config", configPath}
+	if settings.Image != "" {
+		args = append(args, "--image", settings.Image)
+	}
+
+	cmd := exec.CommandContext(ctx, "kind", args...)
+	cmd.Dir = env.ProjectPath
+	if err := runCommand(ctx, cmd, common.ShouldShowTaskOutput()); err != nil {
+		return fmt.Errorf("kind create cluster failed: %w", err)
+	}
+	return nil
+}
+
+func (p *kindProvider) Destroy(ctx context.Context, env *config.Environment) error {
+	settings, err := p.settings(env)
+	if err != nil {
+		return err
+	}
+	if settings.Taskfile {
+		return p.taskfile.Destroy(ctx, env)
+	}
+
+	// Teardown often runs in the background, so never stream its output
+	cmd := exec.CommandContext(ctx, "kind", "delete", "cluster", "--name", kindClusterName(env))
+	cmd.Dir = env.ProjectPath
+	if err := runCommand(ctx, cmd, false); err != nil {
+		return fmt.Errorf("kind delete cluster failed: %w", err)
+	}
+
+	return os.RemoveAll(p.workDir(env))
+}
+
+func (p *kindProvider) Status(ctx context.Context, env *config.Environment) (State, error) {
+	// Taskfile projects may manage kind without having it on the PATH
+	if _, err := exec.LookPath("kind"); err != nil {
+		return StateUnknown, nil
+	}
+
+	output, err := exec.CommandContext(ctx, "kind", "get", "clusters").Output()
+	if err != nil {
+		return StateUnknown, fmt.Errorf("failed to list kind clusters: %w", err)
+	}
+
+	for _, name := range strings.Fields(string(output)) {
+		if name == kindClusterName(env) {
+			return StateRunning, nil
+		}
+	}
+	return StateNotFound, nil
+}
+
+// Outputs exports the cluster kubeconfig to a file of its own and returns its
+// path along with the kubectl context name
+func (p *kindProvider) Outputs(ctx context.Context, env *config.Environment) (map[string]string, error) {
+	if _, err := exec.LookPath("kind"); err != nil {
+		return nil, nil
+	}
+
+	kubeconfig, err := exec.CommandContext(ctx, "kind", "get", "kubeconfig", "--name", kindClusterName(env)).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kubeconfig: %w", err)
+	}
+
+	if err := os.MkdirAll(p.workDir(env), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create kind working directory: %w", err)
+	}
+	path := filepath.Join(p.workDir(env), "kubeconfig")
+	if err := os.WriteFile(path, kubeconfig, 0o600); err != nil {
+		return nil, fmt.Errorf("failed to write kubeconfig: %w", err)
+	}
+
+	return map[string]string{
+		"kubeconfig":      path,
+		"kubectl_context": "kind-" + kindClusterName(env),
+	}, nil
+}
+
+// RunHook runs the lifecycle hooks of projects that set kind.taskfile
+func (p *kindProvider) RunHook(ctx context.Context, env *config.Environment, hook Hook) (bool, error) {
+	settings, err := p.settings(env)
+	if err != nil {
+		return false, err
+	}
+	if !settings.Taskfile {
+		return false, nil
+	}
+	return p.taskfile.RunHook(ctx, env, hook)
+}
+
+// HookFiles returns the Taskfile of projects that set kind.taskfile; kind
+// itself runs no hook files
+func (p *kindProvider) HookFiles(env *config.Environment) ([]string, bool, error) {
+	settings, err := p.settings(env)
+	if err != nil {
+		return nil, false, err
+	}
+	if !settings.Taskfile {
+		return nil, false, nil
+	}
+	return p.taskfile.HookFiles(env)
+}
+
+// Readiness waits for every node to report Ready when kind.readiness.nodes is
+// set and kubectl is installed, then runs the configured probes
+func (p *kindProvider) Readiness(env *config.Environment) (*Readiness, error) {
+	settings, err := p.settings(env)
+	if err != nil {
+		return nil, err
+	}
+
+	var probes []Probe
+	if settings.Readiness.Nodes {
+		if _, err := exec.LookPath("kubectl"); err == nil {
+			probes = append(probes, Probe{Name: "nodes ready", Check: p.nodesReady})
+		}
+	}
+	return newReadiness(settings.Readiness, probes...)
+}
+
+// nodesReady checks that every node of the cluster reports Ready
+func (p *kindProvider) nodesReady(ctx context.Context, env *config.Environment) error {
+	args := []string{"get", "nodes", "--no-headers"}
+	if kubeconfig := env.Outputs["kubeconfig"]; kubeconfig != "" {
+		args = append([]string{"--kubeconfig", kubeconfig}, args...)
+	} else {
+		args = append([]string{"--context", "kind-" + kindClusterName(env)}, args...)
+	}
+
+	output, err := exec.CommandContext(ctx, "kubectl", args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("kubectl get nodes failed: %w, output: %s", err, strings.TrimSpace(string(output)))
+	}
+
+	var nodes, notReady []string
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		nodes = append(nodes, fields[0])
+		// Cordoned nodes report Ready,SchedulingDisabled
+		if strings.Split(fields[1], ",")[0] != "Ready" {
+			notReady = append(notReady, fields[0])
+		}
+	}
+
+	if len(nodes) == 0 {
+		return fmt.Errorf("no nodes registered yet")
+	}
+	if len(notReady) > 0 {
+		return fmt.Errorf("%d of %d nodes not ready: %s", len(notReady), len(nodes), strings.Join(notReady, ", "))
+	}
+	return nil
+}
+
+// kindClusterName returns the name of the environment's kind cluster, its
+// name between KindClusterPrefix and the start of its ID, so that clusters of
+// same-named environments in other projects, or of an earlier create, are
+// never taken for its own. Environments recorded before clusters were
+// prefixed have no ID and keep their plain name.
+func kindClusterName(env *config.Environment) string {
+	if env.ID == "" {
+		return env.Name
+	}
+	id := env.ID
+	if len(id) > kindClusterIDLength {
+		id = id[:kindClusterIDLength]
+	}
+	return KindClusterPrefix + env.Name + "-" + id
+}
+
+// parseKindClusterName returns the environment name and ID prefix of a
+// cluster named by kindClusterName, false for clusters dick didn't create
+func parseKindClusterName(name string) (envName, id string, ok bool) {
+	rest, ok := strings.CutPrefix(name, KindClusterPrefix)
+	if !ok {
+		return "", "", false
+	}
+	i := strings.LastIndex(rest, "-")
+	if i <= 0 || len(rest)-i-1 != kindClusterIDLength {
+		return "", "", false
+	}
+	if _, err := hex.DecodeString(rest[i+1:]); err != nil {
+		return "", "", false
+	}
+	return rest[:i], rest[i+1:], true
+}
+
+// settings reads the kind section of the environment's configuration, with
+// the parameters of the environment expanded in image and config
+func (p *kindProvider) settings(env *config.Environment) (config.KindConfig, error) {
+	cfg, err := config.LoadEnvironmentConfig(env)
+	if err != nil {
+		return config.KindConfig{}, err
+	}
+	settings := cfg.Kind
+	settings.Image = expandParams(settings.Image, env)
+	settings.Config = expandParams(settings.Config, env)
+	return settings, nil
+}
+
+// workDir returns the per-environment directory for generated files
+func (p *kindProvider) workDir(env *config.Environment) string {
+	return filepath.Join(config.StateDir(env.ProjectPath), "kind", env.Name)
+}
+
+// configPath returns the cluster config passed to kind: the configured file,
+// or one generated from the node settings
+func (p *kindProvider) configPath(env *config.Environment, settings config.KindConfig) string {
+	if settings.Config == "" {
+		return filepath.Join(p.workDir(env), "cluster.yaml")
+	}
+	if filepath.IsAbs(settings.Config) {
+		return settings.Config
+	}
+	return filepath.Join(env.ProjectPath, settings.Config)
+}
+
+// kindCluster is the subset of the kind cluster config dick generates
+type kindCluster struct {
+	Kind       string     `yaml:"kind"`
+	APIVersion string     `yaml:"apiVersion"`
+	Nodes      []kindNode `yaml:"nodes"`
+}
+
+type kindNode struct {
+	Role              string            `yaml:"role"`
+	ExtraPortMappings []kindPortMapping `yaml:"extraPortMappings,omitempty"`
+}
+
+type kindPortMapping struct {
+	ContainerPort int    `yaml:"containerPort"`
+	HostPort      int    `yaml:"hostPort"`
+	Protocol      string `yaml:"protocol,omitempty"`
+	ListenAddress string `yaml:"listenAddress,omitempty"`
+}
+
+// writeClusterConfig generates the kind cluster config from .dick.yaml
+func (p *kindProvider) writeClusterConfig(env *config.Environment, settings config.KindConfig) error {
+	cluster := kindCluster{
+		Kind:       "Cluster",
+		APIVersion: "kind.x-k8s.io/v1alpha4",
+	}
+
+	for i := 0; i < settings.ControlPlanes; i++ {
+		cluster.Nodes = append(cluster.Nodes, kindNode{Role: "control-plane"})
+	}
+	for i := 0; i < settings.Workers; i++ {
+		cluster.Nodes = append(cluster.Nodes, kindNode{Role: "worker"})
+	}
+
+	// Port mappings only make sense on a single node, use the first control plane
+	for _, mapping := range settings.PortMappings {
+		cluster.Nodes[0].ExtraPortMappings = append(cluster.Nodes[0].ExtraPortMappings, kindPortMapping{
+			ContainerPort: mapping.ContainerPort,
+			HostPort:      mapping.HostPort,
+			Protocol:      strings.ToUpper(mapping.Protocol),
+			ListenAddress: mapping.ListenAddress,
+		})
+	}
+
+	data, err := yaml.Marshal(cluster)
+	if err != nil {
+		return fmt.Errorf("failed to encode kind config: %w", err)
+	}
+
+	if err := os.MkdirAll(p.workDir(env), 0o755); err != nil {
+		return fmt.Errorf("failed to create kind working directory: %w", err)
+	}
+	if err := os.WriteFile(p.configPath(env, settings), data, 0o644); err != nil {
+		return fmt.Errorf("failed to write kind config: %w", err)
+	}
+	return nil
+}
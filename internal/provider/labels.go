@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/killallgit/dick/internal/config"
+)
+
+// Everything dick creates is marked so 'dick gc' can find what outlived its
+// environment: kind clusters by their name, docker resources by labels
+const (
+	// KindClusterPrefix starts the name of every kind cluster dick creates
+	KindClusterPrefix = "dick-"
+
+	// kindClusterIDLength is how much of the environment ID ends the name of
+	// its kind cluster, short enough for kind's node hostnames
+	kindClusterIDLength = 8
+
+	// Labels are fixed when a resource is created, so they carry nothing
+	// that changes over an environment's life, e.g. its expiry; gc reads
+	// that from the environment's record
+	LabelEnvID   = "dev.dick.env-id"
+	LabelEnv     = "dev.dick.env"
+	LabelProject = "dev.dick.project"
+	LabelOwner   = "dev.dick.owner"
+)
+
+// Labels returns the labels identifying the resources of an environment
+func Labels(env *config.Environment) map[string]string {
+	return map[string]string{
+		LabelEnvID:   env.ID,
+		LabelEnv:     env.Name,
+		LabelProject: env.ProjectPath,
+		LabelOwner:   owner(),
+	}
+}
+
+// LabelFile returns the path of the environment's label file, which hooks
+// pass to 'docker run --label-file'
+func LabelFile(env *config.Environment) string {
+	return filepath.Join(config.StateDir(env.ProjectPath), "labels", env.Name)
+}
+
+// WriteLabelFile writes the labels of an environment to its label file
+func WriteLabelFile(env *config.Environment) error {
+	labels := Labels(env)
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, key := range keys {
+		fmt.Fprintf(&b, "%s=%s\n", key, labels[key])
+	}
+
+	path := LabelFile(env)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create label directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(b.String()), 0o644); err != nil {
+		return fmt.Errorf("failed to write label file: %w", err)
+	}
+	return nil
+}
+
+// CurrentOwner returns the owner label of the resources the current user
+// creates
+func CurrentOwner() string {
+	return owner()
+}
+
+// owner returns the name of the user running dick
+func owner() string {
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	if name := os.Getenv("USER"); name != "" {
+		return name
+	}
+	return os.Getenv("USERNAME")
+}
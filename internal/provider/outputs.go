@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/killallgit/dick/internal/config"
+)
+
+// outputsFileName is the file in the outputs directory hooks write
+// key=value outputs to
+const outputsFileName = "outputs.env"
+
+// OutputsDir returns the directory hooks of an environment keep generated
+// files in, e.g. a kubeconfig
+func OutputsDir(env *config.Environment) string {
+	return filepath.Join(config.StateDir(env.ProjectPath), "outputs", env.Name)
+}
+
+// OutputsFile returns the file hooks write key=value outputs to
+func OutputsFile(env *config.Environment) string {
+	return filepath.Join(OutputsDir(env), outputsFileName)
+}
+
+// PrepareOutputs gives a new environment an empty outputs directory
+func PrepareOutputs(env *config.Environment) error {
+	if err := os.RemoveAll(OutputsDir(env)); err != nil {
+		return fmt.Errorf("failed to clear outputs directory: %w", err)
+	}
+	if err := os.MkdirAll(OutputsDir(env), 0o755); err != nil {
+		return fmt.Errorf("failed to create outputs directory: %w", err)
+	}
+	return nil
+}
+
+// CollectOutputs returns the outputs of an environment: the values its
+// provider exposes, overridden by those its hooks wrote to the outputs file
+func CollectOutputs(ctx context.Context, p Provider, env *config.Environment) (map[string]string, error) {
+	outputs, err := p.Outputs(ctx, env)
+
+	written, readErr := readOutputsFile(OutputsFile(env))
+	if len(written) > 0 && outputs == nil {
+		outputs = make(map[string]string, len(written))
+	}
+	for key, value := range written {
+		outputs[key] = value
+	}
+	return outputs, errors.Join(err, readErr)
+}
+
+// readOutputsFile parses key=value lines, skipping blank lines and #
+// comments. Later lines win. A missing file has no outputs.
+func readOutputsFile(path string) (map[string]string, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read outputs file: %w", err)
+	}
+	defer file.Close()
+
+	outputs := make(map[string]string)
+	scanner := bufio.NewScanner(file)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return outputs, fmt.Errorf("%s:%d: expected key=value", path, n)
+		}
+		outputs[key] = strings.TrimSpace(value)
+	}
+	if err := scanner.Err(); err != nil {
+		return outputs, fmt.Errorf("failed to read outputs file: %w", err)
+	}
+	return outputs, nil
+}
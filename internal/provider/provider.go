@@ -0,0 +1,98 @@
+// Package provider defines how dick creates and destroys environments and
+// keeps the registry of available providers.
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/killallgit/dick/internal/config"
+)
+
+// State is the observed state of an environment's infrastructure
+type State string
+
+const (
+	// StateRunning means the infrastructure exists
+	StateRunning State = "running"
+
+	// StateNotFound means the infrastructure does not exist
+	StateNotFound State = "not-found"
+
+	// StateUnknown means the provider cannot tell
+	StateUnknown State = "unknown"
+)
+
+// Provider creates and destroys one kind of environment
+type Provider interface {
+	// Name returns the name used in .dick.yaml and --provider
+	Name() string
+
+	// Description returns a short human readable summary
+	Description() string
+
+	// Validate checks that the environment can be created with this provider,
+	// e.g. that required tools and files are present
+	Validate(env *config.Environment) error
+
+	// Create provisions the infrastructure of the environment
+	Create(ctx context.Context, env *config.Environment) error
+
+	// Destroy tears down the infrastructure of the environment
+	Destroy(ctx context.Context, env *config.Environment) error
+
+	// Status reports the observed state of the infrastructure. 'dick
+	// reconcile' repairs records based on it, so providers that can't tell
+	// whether the infrastructure exists report StateUnknown instead of guessing.
+	Status(ctx context.Context, env *config.Environment) (State, error)
+
+	// Outputs returns values exposed by the environment, e.g. a kubeconfig path
+	Outputs(ctx context.Context, env *config.Environment) (map[string]string, error)
+}
+
+// registry holds every registered provider keyed by name
+var registry = make(map[string]Provider)
+
+func init() {
+	// Let config validate provider names without importing the implementations
+	config.SetProviderNames(Names)
+}
+
+// Register makes a provider available by name. It panics on duplicate names
+// since that is a programming error.
+func Register(p Provider) {
+	if _, exists := registry[p.Name()]; exists {
+		panic(fmt.Sprintf("provider %s registered twice", p.Name()))
+	}
+	registry[p.Name()] = p
+}
+
+// Get returns the named provider
+func Get(name string) (Provider, error) {
+	p, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("unsupported provider '%s' (supported: %s)", name, strings.Join(Names(), ", "))
+	}
+	return p, nil
+}
+
+// Names returns the names of all registered providers, sorted
+func Names() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// All returns all registered providers sorted by name
+func All() []Provider {
+	providers := make([]Provider, 0, len(registry))
+	for _, name := range Names() {
+		providers = append(providers, registry[name])
+	}
+	return providers
+}
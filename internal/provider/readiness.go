@@ -0,0 +1,196 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/killallgit/dick/internal/config"
+)
+
+const (
+	// defaultReadinessTimeout bounds the probes when no timeout is configured
+	defaultReadinessTimeout = 5 * time.Minute
+
+	// defaultReadinessInterval is the pause between probe rounds
+	defaultReadinessInterval = 5 * time.Second
+
+	// httpProbeTimeout bounds a single request of an HTTP probe
+	httpProbeTimeout = 10 * time.Second
+)
+
+// Probe is a single readiness check of a new environment
+type Probe struct {
+	// Name describes the probe in messages, e.g. "http https://localhost"
+	Name string
+
+	// Check returns nil once the probe passes
+	Check func(ctx context.Context, env *config.Environment) error
+}
+
+// Readiness is what a new environment has to pass before it becomes active
+type Readiness struct {
+	Probes   []Probe
+	Timeout  time.Duration
+	Interval time.Duration
+
+	// Keep leaves an environment that never became ready in place until its
+	// TTL expires instead of destroying it
+	Keep bool
+}
+
+// ReadinessProvider is implemented by providers whose environments have to
+// pass readiness probes before they are used
+type ReadinessProvider interface {
+	// Readiness returns the probes of the environment, nil if it has none
+	Readiness(env *config.Environment) (*Readiness, error)
+}
+
+// GetReadiness returns the readiness probes of an environment, nil if its
+// provider has none
+func GetReadiness(p Provider, env *config.Environment) (*Readiness, error) {
+	rp, ok := p.(ReadinessProvider)
+	if !ok {
+		return nil, nil
+	}
+	readiness, err := rp.Readiness(env)
+	if err != nil || readiness == nil || len(readiness.Probes) == 0 {
+		return nil, err
+	}
+	return readiness, nil
+}
+
+// newReadiness builds the readiness of an environment from its settings.
+// Provider specific probes run before the command and HTTP probes.
+func newReadiness(settings config.ReadinessConfig, probes ...Probe) (*Readiness, error) {
+	readiness := &Readiness{
+		Probes:   probes,
+		Timeout:  defaultReadinessTimeout,
+		Interval: defaultReadinessInterval,
+		Keep:     settings.OnFailure == "keep",
+	}
+
+	var err error
+	if settings.Timeout != "" {
+		if readiness.Timeout, err = time.ParseDuration(settings.Timeout); err != nil {
+			return nil, fmt.Errorf("invalid readiness timeout '%s': %w", settings.Timeout, err)
+		}
+	}
+	if settings.Interval != "" {
+		if readiness.Interval, err = time.ParseDuration(settings.Interval); err != nil {
+			return nil, fmt.Errorf("invalid readiness interval '%s': %w", settings.Interval, err)
+		}
+	}
+
+	if settings.Command != "" {
+		readiness.Probes = append(readiness.Probes, commandProbe(settings.Command))
+	}
+	if settings.HTTP != "" {
+		readiness.Probes = append(readiness.Probes, httpProbe(settings.HTTP))
+	}
+	return readiness, nil
+}
+
+// Wait runs the probes in rounds until all of them pass. Once the timeout
+// passes it returns the error of the last failed probe.
+func (r *Readiness) Wait(ctx context.Context, env *config.Environment) error {
+	ctx, cancel := context.WithTimeout(ctx, r.Timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(r.Interval)
+	defer ticker.Stop()
+
+	var lastErr error
+	for {
+		err := r.check(ctx, env)
+		if err == nil {
+			return nil
+		}
+		// A probe cut short by the timeout says less than the round before
+		if ctx.Err() == nil || lastErr == nil {
+			lastErr = err
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return fmt.Errorf("not ready after %s: %w", r.Timeout, lastErr)
+			}
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// check runs every probe once and returns the first failure
+func (r *Readiness) check(ctx context.Context, env *config.Environment) error {
+	for _, probe := range r.Probes {
+		if err := probe.Check(ctx, env); err != nil {
+			return fmt.Errorf("%s: %w", probe.Name, err)
+		}
+	}
+	return nil
+}
+
+// commandProbe passes when a shell command run in the project directory
+// exits with status 0. It sees the same variables as 'dick run' commands.
+func commandProbe(command string) Probe {
+	return Probe{
+		Name: "command " + command,
+		Check: func(ctx context.Context, env *config.Environment) error {
+			cmd := shellCommand(ctx, command)
+			cmd.Dir = env.ProjectPath
+			cmd.Env = append(os.Environ(), Environ(env)...)
+
+			var output bytes.Buffer
+			cmd.Stdout = &output
+			cmd.Stderr = &output
+			if err := cmd.Run(); err != nil {
+				if out := strings.TrimSpace(output.String()); out != "" {
+					return fmt.Errorf("%w, output: %s", err, out)
+				}
+				return err
+			}
+			return nil
+		},
+	}
+}
+
+// shellCommand runs a command line through the platform shell
+func shellCommand(ctx context.Context, command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", command)
+	}
+	return exec.CommandContext(ctx, "sh", "-c", command)
+}
+
+// httpProbe passes when a GET of the URL answers with a 2xx or 3xx status
+func httpProbe(url string) Probe {
+	client := &http.Client{Timeout: httpProbeTimeout}
+	return Probe{
+		Name: "http " + url,
+		Check: func(ctx context.Context, env *config.Environment) error {
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+			if err != nil {
+				return err
+			}
+			resp, err := client.Do(req)
+			if err != nil {
+				return err
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+				return fmt.Errorf("unexpected status %s", resp.Status)
+			}
+			return nil
+		},
+	}
+}
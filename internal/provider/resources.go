@@ -0,0 +1,164 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/killallgit/dick/internal/config"
+)
+
+// Resource is infrastructure dick created, found by the name prefix or labels
+// it gives everything it creates
+type Resource struct {
+	// Type describes the resource, e.g. "kind cluster"
+	Type string
+	Name string
+
+	// Provider is the provider whose Destroy removes the resource, empty for
+	// resources created by hooks
+	Provider string
+
+	// EnvName is the name of the environment that created the resource.
+	// The other fields are only known from docker labels.
+	EnvName string
+	EnvID   string
+	Project string
+	Owner   string
+
+	belongs func(env *config.Environment) bool
+	delete  func(ctx context.Context) error
+}
+
+// BelongsTo reports whether the resource is part of the environment
+func (r Resource) BelongsTo(env *config.Environment) bool {
+	return r.belongs(env)
+}
+
+// Delete removes the resource
+func (r Resource) Delete(ctx context.Context) error {
+	return r.delete(ctx)
+}
+
+// ResourceFinder is implemented by providers that can find the resources
+// they created, whether or not an environment still records them
+type ResourceFinder interface {
+	Resources(ctx context.Context) ([]Resource, error)
+}
+
+// Resources returns every resource dick created that still exists: those
+// found by the providers and docker containers carrying dick's labels
+func Resources(ctx context.Context) ([]Resource, error) {
+	var resources []Resource
+	var errs []error
+
+	for _, p := range All() {
+		finder, ok := p.(ResourceFinder)
+		if !ok {
+			continue
+		}
+		found, err := finder.Resources(ctx)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", p.Name(), err))
+		}
+		resources = append(resources, found...)
+	}
+
+	containers, err := dockerContainers(ctx)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("docker: %w", err))
+	}
+	resources = append(resources, containers...)
+
+	return resources, errors.Join(errs...)
+}
+
+// Resources lists the kind clusters named by kindClusterName
+func (p *kindProvider) Resources(ctx context.Context) ([]Resource, error) {
+	if _, err := exec.LookPath("kind"); err != nil {
+		return nil, nil
+	}
+
+	output, err := exec.CommandContext(ctx, "kind", "get", "clusters").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list kind clusters: %w", err)
+	}
+
+	var resources []Resource
+	for _, name := range strings.Fields(string(output)) {
+		envName, _, ok := parseKindClusterName(name)
+		if !ok {
+			continue
+		}
+		resources = append(resources, Resource{
+			Type:     "kind cluster",
+			Name:     name,
+			Provider: p.Name(),
+			EnvName:  envName,
+			belongs: func(env *config.Environment) bool {
+				return env.Provider == p.Name() && kindClusterName(env) == name
+			},
+			delete: func(ctx context.Context) error {
+				cmd := exec.CommandContext(ctx, "kind", "delete", "cluster", "--name", name)
+				if err := runCommand(ctx, cmd, false); err != nil {
+					return fmt.Errorf("kind delete cluster failed: %w", err)
+				}
+				return nil
+			},
+		})
+	}
+	return resources, nil
+}
+
+// dockerContainers lists the docker containers labeled with an environment ID
+func dockerContainers(ctx context.Context) ([]Resource, error) {
+	if _, err := exec.LookPath("docker"); err != nil {
+		return nil, nil
+	}
+
+	format := strings.Join([]string{
+		"{{.ID}}",
+		"{{.Names}}",
+		fmt.Sprintf("{{.Label %q}}", LabelEnvID),
+		fmt.Sprintf("{{.Label %q}}", LabelEnv),
+		fmt.Sprintf("{{.Label %q}}", LabelProject),
+		fmt.Sprintf("{{.Label %q}}", LabelOwner),
+	}, "\t")
+	output, err := exec.CommandContext(ctx, "docker", "ps", "--all",
+		"--filter", "label="+LabelEnvID, "--format", format).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list docker containers: %w", err)
+	}
+
+	var resources []Resource
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		fields := strings.Split(line, "\t")
+		if len(fields) != 6 {
+			continue
+		}
+		id, envID := fields[0], fields[2]
+		resources = append(resources, Resource{
+			Type:    "docker container",
+			Name:    fields[1],
+			EnvID:   envID,
+			EnvName: fields[3],
+			Project: fields[4],
+			Owner:   fields[5],
+			belongs: func(env *config.Environment) bool {
+				return envID != "" && env.ID == envID
+			},
+			delete: func(ctx context.Context) error {
+				cmd := exec.CommandContext(ctx, "docker", "rm", "--force", "--volumes", id)
+				err := runCommand(ctx, cmd, false)
+				// The teardown of its environment may have removed it already
+				if err != nil && !strings.Contains(err.Error(), "No such container") {
+					return fmt.Errorf("docker rm failed: %w", err)
+				}
+				return nil
+			},
+		})
+	}
+	return resources, nil
+}
@@ -0,0 +1,234 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	task "github.com/go-task/task/v3"
+	taskerrors "github.com/go-task/task/v3/errors"
+	"github.com/go-task/task/v3/experiments"
+	"github.com/go-task/task/v3/taskfile/ast"
+
+	"github.com/killallgit/dick/internal/common"
+	"github.com/killallgit/dick/internal/config"
+)
+
+const (
+	// setupTask and teardownTask are the standardized Taskfile hooks
+	setupTask    = "hook:setup"
+	teardownTask = "hook:teardown"
+)
+
+// init turns on the Task experiment letting the env of a Taskfile override
+// the environment of the process. Experiments are process-wide in go-task,
+// and every Taskfile dick runs needs this one, so it is set once here rather
+// than per executor. It is set directly rather than parsed, which would read
+// the .taskrc and .env of whichever project came first. Written against
+// go-task v3.43.3, whose executor only checks
+// experiments.EnvPrecedence.Enabled(); recheck on upgrades.
+func init() {
+	experiments.EnvPrecedence = experiments.Experiment{
+		Name:          "ENV_PRECEDENCE",
+		AllowedValues: []int{1},
+		Value:         1,
+	}
+}
+
+// taskfileProvider runs the standardized setup and teardown hooks of a
+// Taskfile in the project's tasks directory
+type taskfileProvider struct {
+	name        string
+	description string
+	taskfile    string
+
+	// Legacy Taskfile and task names used when taskfile is missing
+	legacyTaskfile string
+	legacySetup    string
+	legacyTeardown string
+
+	// clusterName returns the CLUSTER_NAME passed to the tasks, the
+	// environment name when nil
+	clusterName func(env *config.Environment) string
+}
+
+func (p *taskfileProvider) Name() string        { return p.name }
+func (p *taskfileProvider) Description() string { return p.description }
+
+func (p *taskfileProvider) Validate(env *config.Environment) error {
+	if _, _, _, err := p.resolve(env.ProjectPath); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (p *taskfileProvider) Create(ctx context.Context, env *config.Environment) error {
+	taskFile, setup, _, err := p.resolve(env.ProjectPath)
+	if err != nil {
+		return err
+	}
+	return runTask(ctx, env, taskFile, setup, p.cluster(env), common.ShouldShowTaskOutput())
+}
+
+func (p *taskfileProvider) Destroy(ctx context.Context, env *config.Environment) error {
+	taskFile, _, teardown, err := p.resolve(env.ProjectPath)
+	if err != nil {
+		return err
+	}
+	// Teardown often runs in the background (TTL timer, reaper), where
+	// streaming output would garble the monitor; log it instead
+	return runTask(ctx, env, taskFile, teardown, p.cluster(env), false)
+}
+
+// Status is unknown since Taskfiles have no standardized status hook
+func (p *taskfileProvider) Status(ctx context.Context, env *config.Environment) (State, error) {
+	return StateUnknown, nil
+}
+
+// Outputs is empty since Taskfiles have no standardized way to expose values
+func (p *taskfileProvider) Outputs(ctx context.Context, env *config.Environment) (map[string]string, error) {
+	return nil, nil
+}
+
+// RunHook runs the hook:<hook> task of the Taskfile, if it defines one.
+// Hooks run while dick is in the foreground stream their output in verbose
+// mode; the others may run in the background and never stream.
+func (p *taskfileProvider) RunHook(ctx context.Context, env *config.Environment, hook Hook) (bool, error) {
+	taskFile, _, _, err := p.resolve(env.ProjectPath)
+	if err != nil {
+		return false, err
+	}
+
+	name := "hook:" + string(hook)
+	defined, err := hasTask(env, taskFile, name)
+	if err != nil || !defined {
+		return false, err
+	}
+
+	stream := common.ShouldShowTaskOutput() &&
+		(hook == HookPreCreate || hook == HookHealthcheck || hook == HookPostCreate)
+	return true, runTask(ctx, env, taskFile, name, p.cluster(env), stream)
+}
+
+// cluster returns the CLUSTER_NAME of the environment
+func (p *taskfileProvider) cluster(env *config.Environment) string {
+	if p.clusterName == nil {
+		return env.Name
+	}
+	return p.clusterName(env)
+}
+
+// HookFiles returns the Taskfile run for the environment
+func (p *taskfileProvider) HookFiles(env *config.Environment) ([]string, bool, error) {
+	taskFile, _, _, err := p.resolve(env.ProjectPath)
+	if err != nil {
+		return nil, false, err
+	}
+	return []string{taskFile}, strings.HasPrefix(taskFile, BuiltinHooksDir(env.ProjectPath, p.name)), nil
+}
+
+// resolve returns the Taskfile and the setup and teardown task names to use,
+// falling back to the legacy Taskfile for backward compatibility and then to
+// the built-in Taskfile
+func (p *taskfileProvider) resolve(projectDir string) (taskFile, setup, teardown string, err error) {
+	taskFile = filepath.Join(projectDir, "tasks", p.taskfile)
+	if _, err := os.Stat(taskFile); err == nil {
+		return taskFile, setupTask, teardownTask, nil
+	}
+
+	tried := []string{taskFile}
+	if p.legacyTaskfile != "" {
+		legacy := filepath.Join(projectDir, "tasks", p.legacyTaskfile)
+		if _, err := os.Stat(legacy); err == nil {
+			return legacy, p.legacySetup, p.legacyTeardown, nil
+		}
+		tried = append(tried, legacy)
+	}
+
+	builtin, err := builtinHookFile(projectDir, p.name, "tasks/"+p.taskfile)
+	if err != nil {
+		return "", "", "", fmt.Errorf("failed to write built-in taskfile for provider %s: %w", p.name, err)
+	}
+	if _, err := os.Stat(builtin); err == nil {
+		return builtin, setupTask, teardownTask, nil
+	}
+
+	return "", "", "", fmt.Errorf("no taskfile found for provider %s: tried %s", p.name, strings.Join(tried, " and "))
+}
+
+// hasTask reports whether a Taskfile, or one it includes, defines a task
+func hasTask(env *config.Environment, taskFile, name string) (bool, error) {
+	e, err := newTaskExecutor(env, taskFile, "", io.Discard, io.Discard)
+	if err != nil {
+		return false, err
+	}
+
+	var notFound *taskerrors.TaskNotFoundError
+	if _, err := e.GetTask(&task.Call{Task: name}); errors.As(err, &notFound) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// runTask runs a task of a Taskfile in-process, as the task CLI would run
+// task -t <taskfile> <task> CLUSTER_NAME=<name>. The task sees the variables
+// of Environ, e.g. DICK_LABEL_FILE.
+func runTask(ctx context.Context, env *config.Environment, taskFile, taskName, clusterName string, stream bool) error {
+	args := []string{"task", "-t", taskFile, taskName, "CLUSTER_NAME=" + clusterName}
+
+	err := runWithOutput(ctx, args, stream, func(stdout, stderr io.Writer) error {
+		e, err := newTaskExecutor(env, taskFile, clusterName, stdout, stderr)
+		if err != nil {
+			return err
+		}
+		return e.Run(ctx, &task.Call{Task: taskName})
+	})
+	if err != nil {
+		return fmt.Errorf("task execution failed: %w", err)
+	}
+	return nil
+}
+
+// newTaskExecutor loads a Taskfile for running its tasks in the project
+// directory. Commands echo unless task output is hidden and see the
+// variables of Environ, which take precedence over the environment of dick
+// as they did when task ran as a child process.
+func newTaskExecutor(env *config.Environment, taskFile, clusterName string, stdout, stderr io.Writer) (*task.Executor, error) {
+	e := task.NewExecutor(
+		task.WithEntrypoint(taskFile),
+		task.WithSilent(!common.ShouldShowTaskOutput()),
+		task.WithStdin(strings.NewReader("")),
+		task.WithStdout(stdout),
+		task.WithStderr(stderr),
+	)
+	// Relative paths of the CLI resolve against the project, e.g. USER_WORKING_DIR
+	e.UserWorkingDir = env.ProjectPath
+
+	if err := e.Setup(); err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", taskFile, err)
+	}
+
+	vars := ast.NewVars()
+	for _, variable := range Environ(env) {
+		key, value, _ := strings.Cut(variable, "=")
+		vars.Set(key, ast.Var{Value: value})
+	}
+	// First, so the Taskfile's own env can refer to them, and last, so they win
+	taskfileEnv := ast.NewVars()
+	taskfileEnv.Merge(vars, nil)
+	taskfileEnv.Merge(e.Taskfile.Env, nil)
+	taskfileEnv.Merge(vars, nil)
+	e.Taskfile.Env = taskfileEnv
+	e.Compiler.TaskfileEnv = taskfileEnv
+
+	if clusterName != "" {
+		e.Taskfile.Vars.Set("CLUSTER_NAME", ast.Var{Value: clusterName})
+	}
+	return e, nil
+}
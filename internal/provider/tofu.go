@@ -0,0 +1,334 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/killallgit/dick/internal/common"
+	"github.com/killallgit/dick/internal/config"
+)
+
+// tofuProvider applies an OpenTofu root module once per environment. Each
+// environment gets its own working directory under .dick/tofu/<name> holding
+// a copy of the module, its state, plan and provider plugins, so environments
+// never share state and 'tofu -chdir=.dick/tofu/<name> output' sees what dick
+// sees. Files outside the module directory are not copied.
+type tofuProvider struct{}
+
+func init() {
+	Register(&tofuProvider{})
+}
+
+func (p *tofuProvider) Name() string { return "tofu" }
+
+func (p *tofuProvider) Description() string {
+	return "OpenTofu module with per-environment state"
+}
+
+func (p *tofuProvider) Validate(env *config.Environment) error {
+	settings, err := p.settings(env)
+	if err != nil {
+		return err
+	}
+
+	if _, err := exec.LookPath(settings.Binary); err != nil {
+		return fmt.Errorf("%s command not found. Please install OpenTofu: https://opentofu.org/docs/intro/install/", settings.Binary)
+	}
+
+	module := p.moduleDir(env, settings)
+	if len(moduleFiles(module)) == 0 {
+		return fmt.Errorf("no tofu module found in %s (set tofu.module in .dick.yaml)", module)
+	}
+
+	return nil
+}
+
+func (p *tofuProvider) Create(ctx context.Context, env *config.Environment) error {
+	settings, err := p.settings(env)
+	if err != nil {
+		return err
+	}
+
+	if err := copyModule(p.moduleDir(env, settings), p.workDir(env)); err != nil {
+		return fmt.Errorf("failed to prepare tofu working directory: %w", err)
+	}
+
+	stream := common.ShouldShowTaskOutput()
+	planFile := filepath.Join(p.workDir(env), "tfplan")
+	defer os.Remove(planFile)
+
+	if err := p.run(ctx, env, settings, stream, "init", "-input=false"); err != nil {
+		return err
+	}
+
+	planArgs := []string{"plan", "-input=false", "-out=" + planFile}
+	if err := p.run(ctx, env, settings, stream, append(planArgs, p.varArgs(env, settings)...)...); err != nil {
+		return err
+	}
+
+	return p.run(ctx, env, settings, stream, "apply", "-input=false", planFile)
+}
+
+func (p *tofuProvider) Destroy(ctx context.Context, env *config.Environment) error {
+	// Nothing was ever applied
+	if _, err := os.Stat(p.statePath(env)); errors.Is(err, os.ErrNotExist) {
+		return os.RemoveAll(p.workDir(env))
+	}
+
+	settings, err := p.prepare(env)
+	if err != nil {
+		return err
+	}
+
+	// Teardown often runs in the background, so never stream its output
+	if err := p.run(ctx, env, settings, false, "init", "-input=false"); err != nil {
+		return err
+	}
+
+	destroyArgs := []string{"apply", "-destroy", "-auto-approve", "-input=false"}
+	if err := p.run(ctx, env, settings, false, append(destroyArgs, p.varArgs(env, settings)...)...); err != nil {
+		return err
+	}
+
+	return os.RemoveAll(p.workDir(env))
+}
+
+func (p *tofuProvider) Status(ctx context.Context, env *config.Environment) (State, error) {
+	if _, err := os.Stat(p.statePath(env)); errors.Is(err, os.ErrNotExist) {
+		return StateNotFound, nil
+	}
+
+	settings, err := p.prepare(env)
+	if err != nil {
+		return StateUnknown, err
+	}
+
+	output, err := p.command(ctx, env, settings, "state", "list").Output()
+	if err != nil {
+		return StateUnknown, fmt.Errorf("failed to list tofu state: %w", err)
+	}
+	if strings.TrimSpace(string(output)) == "" {
+		return StateNotFound, nil
+	}
+	return StateRunning, nil
+}
+
+// Outputs returns the root module outputs. Non-string values are JSON encoded.
+func (p *tofuProvider) Outputs(ctx context.Context, env *config.Environment) (map[string]string, error) {
+	if _, err := os.Stat(p.statePath(env)); errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	settings, err := p.prepare(env)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := p.command(ctx, env, settings, "output", "-json").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tofu outputs: %w", err)
+	}
+
+	var raw map[string]struct {
+		Value json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse tofu outputs: %w", err)
+	}
+
+	outputs := make(map[string]string, len(raw))
+	for name, output := range raw {
+		var value string
+		if err := json.Unmarshal(output.Value, &value); err != nil {
+			value = string(output.Value)
+		}
+		outputs[name] = value
+	}
+	return outputs, nil
+}
+
+// Readiness runs the configured command and HTTP probes
+func (p *tofuProvider) Readiness(env *config.Environment) (*Readiness, error) {
+	settings, err := p.settings(env)
+	if err != nil {
+		return nil, err
+	}
+	return newReadiness(settings.Readiness)
+}
+
+// settings reads the tofu section of the environment's configuration.
+// Projects without a module in the default tofu/ directory use the built-in
+// module.
+func (p *tofuProvider) settings(env *config.Environment) (config.TofuConfig, error) {
+	cfg, err := config.LoadEnvironmentConfig(env)
+	if err != nil {
+		return config.TofuConfig{}, err
+	}
+
+	settings := cfg.Tofu
+	if settings.Binary == "" {
+		settings.Binary = "tofu"
+	}
+	if settings.Module == "" {
+		settings.Module = "tofu"
+	}
+	if settings.Module == "tofu" && len(moduleFiles(p.moduleDir(env, settings))) == 0 {
+		builtin, err := builtinHookFile(env.ProjectPath, p.Name(), "tofu")
+		if err != nil {
+			return config.TofuConfig{}, fmt.Errorf("failed to write built-in tofu module: %w", err)
+		}
+		settings.Module = builtin
+	}
+	return settings, nil
+}
+
+// prepare returns the settings for running tofu against an applied
+// environment. Working directories of environments created before the module
+// was copied into them get a copy of the module first.
+func (p *tofuProvider) prepare(env *config.Environment) (config.TofuConfig, error) {
+	settings, err := p.settings(env)
+	if err != nil {
+		return config.TofuConfig{}, err
+	}
+
+	if len(moduleFiles(p.workDir(env))) == 0 {
+		if err := copyModule(p.moduleDir(env, settings), p.workDir(env)); err != nil {
+			return config.TofuConfig{}, fmt.Errorf("failed to prepare tofu working directory: %w", err)
+		}
+	}
+	return settings, nil
+}
+
+// copyModule copies a module directory with its subdirectories into a
+// working directory, leaving out the state and plugins of runs in the module
+// directory itself, and the state directory of a module at the project root
+func copyModule(module, workDir string) error {
+	return filepath.WalkDir(module, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(module, path)
+		if err != nil {
+			return err
+		}
+		name := entry.Name()
+		if entry.IsDir() {
+			switch name {
+			case ".terraform", ".git", "terraform.tfstate.d", config.StateDirName:
+				if rel != "." {
+					return filepath.SkipDir
+				}
+			}
+			return os.MkdirAll(filepath.Join(workDir, rel), 0o755)
+		}
+		if strings.HasPrefix(name, "terraform.tfstate") || !entry.Type().IsRegular() {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(filepath.Join(workDir, rel), data, 0o644)
+	})
+}
+
+// HookFiles returns the files of the root module applied for the environment
+func (p *tofuProvider) HookFiles(env *config.Environment) ([]string, bool, error) {
+	settings, err := p.settings(env)
+	if err != nil {
+		return nil, false, err
+	}
+	module := p.moduleDir(env, settings)
+	return moduleFiles(module), strings.HasPrefix(module, BuiltinHooksDir(env.ProjectPath, p.Name())), nil
+}
+
+// moduleFiles returns the .tf and .tofu files of a module directory
+func moduleFiles(dir string) []string {
+	files, _ := filepath.Glob(filepath.Join(dir, "*.tf"))
+	tofuFiles, _ := filepath.Glob(filepath.Join(dir, "*.tofu"))
+	return append(files, tofuFiles...)
+}
+
+// moduleDir returns the absolute path of the root module
+func (p *tofuProvider) moduleDir(env *config.Environment, settings config.TofuConfig) string {
+	if filepath.IsAbs(settings.Module) {
+		return settings.Module
+	}
+	return filepath.Join(env.ProjectPath, settings.Module)
+}
+
+// workDir returns the per-environment working directory
+func (p *tofuProvider) workDir(env *config.Environment) string {
+	return filepath.Join(config.StateDir(env.ProjectPath), "tofu", env.Name)
+}
+
+// statePath returns the per-environment state file, the default of the local
+// backend in the working directory
+func (p *tofuProvider) statePath(env *config.Environment) string {
+	return filepath.Join(p.workDir(env), "terraform.tfstate")
+}
+
+// varArgs returns the configured variables as sorted -var arguments.
+// Variables set by a parameter of the environment are left to its TF_VAR_.
+func (p *tofuProvider) varArgs(env *config.Environment, settings config.TofuConfig) []string {
+	keys := make([]string, 0, len(settings.Vars))
+	for key := range settings.Vars {
+		if _, ok := env.Params[key]; ok {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	args := make([]string, 0, len(keys))
+	for _, key := range keys {
+		args = append(args, fmt.Sprintf("-var=%s=%s", key, settings.Vars[key]))
+	}
+	return args
+}
+
+// command builds a tofu invocation in the environment's working directory.
+// Environment metadata is exposed as TF_VAR_dick_* which
+// modules may declare as variables; undeclared ones are ignored. dick_labels
+// is a JSON map of the labels resources should carry for 'dick gc'. Each
+// parameter is passed as TF_VAR_<name>, so modules declare the variables
+// they take.
+func (p *tofuProvider) command(ctx context.Context, env *config.Environment, settings config.TofuConfig, args ...string) *exec.Cmd {
+	labels, _ := json.Marshal(Labels(env))
+
+	cmd := exec.CommandContext(ctx, settings.Binary,
+		append([]string{"-chdir=" + p.workDir(env)}, args...)...)
+	cmd.Env = append(os.Environ(),
+		"TF_IN_AUTOMATION=1",
+		"TF_VAR_dick_env_name="+env.Name,
+		"TF_VAR_dick_env_id="+env.ID,
+		"TF_VAR_dick_labels="+string(labels),
+		"TF_VAR_dick_ttl="+env.TTL,
+		"TF_VAR_dick_project_path="+env.ProjectPath,
+		"TF_VAR_dick_workdir="+p.workDir(env),
+	)
+	for name, value := range env.Params {
+		cmd.Env = append(cmd.Env, "TF_VAR_"+name+"="+value)
+	}
+	// Provisioners, e.g. local-exec, see the same variables as hooks
+	cmd.Env = append(cmd.Env, Environ(env)...)
+	return cmd
+}
+
+// run executes a tofu subcommand
+func (p *tofuProvider) run(ctx context.Context, env *config.Environment, settings config.TofuConfig, stream bool, args ...string) error {
+	if err := runCommand(ctx, p.command(ctx, env, settings, args...), stream); err != nil {
+		return fmt.Errorf("%s %s failed: %w", settings.Binary, args[0], err)
+	}
+	return nil
+}
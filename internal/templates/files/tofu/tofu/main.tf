@@ -0,0 +1,33 @@
+# Root module applied by dick once per environment, with its own state in
+# .dick/tofu/<name>. Replace the example resource with your infrastructure;
+# outputs are stored with the environment and shown by `dick status -v`.
+
+variable "dick_env_name" {
+  type        = string
+  description = "Name of the dick environment (set by dick)"
+}
+
+variable "dick_ttl" {
+  type        = string
+  description = "TTL of the dick environment (set by dick)"
+  default     = ""
+}
+
+# Put these on docker resources so 'dick gc' can find them once the
+# environment is gone, e.g. with a dynamic "labels" block
+variable "dick_labels" {
+  type        = map(string)
+  description = "Labels identifying the dick environment (set by dick)"
+  default     = {}
+}
+
+resource "terraform_data" "environment" {
+  input = {
+    name = var.dick_env_name
+    ttl  = var.dick_ttl
+  }
+}
+
+output "environment_id" {
+  value = terraform_data.environment.id
+}
@@ -0,0 +1,128 @@
+// Package templates holds the provider hook files dick scaffolds into projects
+// and runs as built-in hooks when a project has none.
+package templates
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/killallgit/dick/internal/common"
+)
+
+// files holds one directory per provider, laid out as in the project
+//
+//go:embed all:files
+var files embed.FS
+
+// Template is a hook file scaffolded into a project
+type Template struct {
+	// Provider is the provider the file belongs to
+	Provider string
+
+	// Path is the destination relative to the project, with forward slashes
+	Path string
+}
+
+// source returns the path of the template in the embedded filesystem
+func (t Template) source() string {
+	return path.Join("files", t.Provider, t.Path)
+}
+
+// Read returns the content of the template
+func (t Template) Read() ([]byte, error) {
+	return files.ReadFile(t.source())
+}
+
+// ForProvider returns the templates of a provider, sorted by path. Providers
+// without hook files return none.
+func ForProvider(provider string) ([]Template, error) {
+	root := path.Join("files", provider)
+	if _, err := fs.Stat(files, root); errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+
+	var templates []Template
+	err := fs.WalkDir(files, root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		templates = append(templates, Template{Provider: provider, Path: strings.TrimPrefix(p, root+"/")})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Path < templates[j].Path })
+	return templates, nil
+}
+
+// Scaffold writes the templates of a provider into a project directory and
+// returns the paths written. Existing files are left alone unless overwrite is set.
+func Scaffold(projectDir, provider string, overwrite bool) ([]string, error) {
+	templates, err := ForProvider(provider)
+	if err != nil {
+		return nil, err
+	}
+
+	var written []string
+	for _, t := range templates {
+		dest := filepath.Join(projectDir, filepath.FromSlash(t.Path))
+		if _, err := os.Stat(dest); err == nil && !overwrite {
+			continue
+		}
+
+		data, err := t.Read()
+		if err != nil {
+			return written, err
+		}
+		if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+			return written, fmt.Errorf("failed to create %s: %w", filepath.Dir(dest), err)
+		}
+		if err := os.WriteFile(dest, data, 0o644); err != nil {
+			return written, fmt.Errorf("failed to write %s: %w", dest, err)
+		}
+		written = append(written, dest)
+	}
+
+	return written, nil
+}
+
+// Materialize writes the templates of a provider into dir, where projects
+// without hook files of their own run them from. Files are only rewritten
+// when they differ from the embedded ones, e.g. after an upgrade, so running
+// hooks never see a half-written file.
+func Materialize(dir, provider string) error {
+	templates, err := ForProvider(provider)
+	if err != nil {
+		return err
+	}
+
+	for _, t := range templates {
+		data, err := t.Read()
+		if err != nil {
+			return err
+		}
+
+		dest := filepath.Join(dir, filepath.FromSlash(t.Path))
+		if current, err := os.ReadFile(dest); err == nil && bytes.Equal(current, data) {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", filepath.Dir(dest), err)
+		}
+
+		if err := common.WriteFileAtomic(dest, data, 0o644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", dest, err)
+		}
+	}
+	return nil
+}
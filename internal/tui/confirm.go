@@ -2,10 +2,12 @@ package tui
 
 import (
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/killallgit/dick/internal/common"
 	"github.com/killallgit/dick/internal/styles"
 )
 
@@ -178,4 +180,33 @@ func RunConfirmation(title, message string) (bool, error) {
 	}
 
 	return confirmModel.IsConfirmed(), nil
-}
\ No newline at end of file
+}
+
+// Confirm asks a yes/no question. With --yes the answer is yes, and when
+// nobody can be asked (--no-input or no terminal) it is fallback. Answers
+// that weren't given by the user are logged to stderr.
+func Confirm(title, message string, fallback bool) (bool, error) {
+	if common.YesFlag {
+		logDecision(title, true, "--yes")
+		return true, nil
+	}
+	if !common.IsInteractive() {
+		reason := "no terminal"
+		if common.NoInputFlag {
+			reason = "--no-input"
+		}
+		logDecision(title, fallback, reason)
+		return fallback, nil
+	}
+
+	return RunConfirmation(title, message)
+}
+
+// logDecision reports the answer given to a prompt on the user's behalf
+func logDecision(title string, answer bool, reason string) {
+	decision := "no"
+	if answer {
+		decision = "yes"
+	}
+	fmt.Fprintf(os.Stderr, "%s %s: answered %s (%s)\n", Icon("info"), title, decision, reason)
+}
@@ -16,7 +16,7 @@ type Model struct {
 	views      map[messages.ViewType]views.View
 	
 	// Shared state
-	config      *config.Config
+	env         *config.Environment
 	width       int
 	height      int
 	lastUpdate  time.Time
@@ -30,9 +30,9 @@ type Model struct {
 }
 
 // NewModel creates a new main TUI model
-func NewModel(cfg *config.Config, watchMode bool) *Model {
+func NewModel(env *config.Environment, watchMode bool) *Model {
 	m := &Model{
-		config:      cfg,
+		env:         env,
 		views:       make(map[messages.ViewType]views.View),
 		refreshRate: time.Second,
 		lastUpdate:  time.Now(),
@@ -41,8 +41,8 @@ func NewModel(cfg *config.Config, watchMode bool) *Model {
 	}
 	
 	// Initialize all views
-	m.views[messages.StatusView] = views.NewStatusView(cfg)
-	m.views[messages.MonitorView] = views.NewMonitorView(cfg)
+	m.views[messages.StatusView] = views.NewStatusView(env)
+	m.views[messages.MonitorView] = views.NewMonitorView(env)
 	m.views[messages.HelpView] = views.NewHelpView()
 	
 	// Set initial view based on mode
@@ -140,8 +140,8 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		
 	case messages.ConfigReloadMsg:
 		// Reload config
-		if cfg, err := config.LoadConfig(); err == nil {
-			m.config = cfg
+		if env, err := config.LoadEnvironment(m.env.Name); err == nil {
+			m.env = env
 			// Notify all views of config update
 			for viewType, view := range m.views {
 				if v, cmd := view.Update(msg); cmd != nil {
@@ -12,7 +12,7 @@ import (
 
 // StatusModel represents the status TUI model
 type StatusModel struct {
-	config      *config.Config
+	env         *config.Environment
 	width       int
 	height      int
 	lastUpdate  time.Time
@@ -21,9 +21,9 @@ type StatusModel struct {
 }
 
 // NewStatusModel creates a new status TUI model
-func NewStatusModel(cfg *config.Config) *StatusModel {
+func NewStatusModel(env *config.Environment) *StatusModel {
 	return &StatusModel{
-		config:      cfg,
+		env:         env,
 		refreshRate: time.Second,
 		lastUpdate:  time.Now(),
 	}
@@ -51,8 +51,8 @@ func (m *StatusModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Quit
 		case "r":
 			// Refresh config
-			if cfg, err := config.LoadConfig(); err == nil {
-				m.config = cfg
+			if env, err := config.LoadEnvironment(m.env.Name); err == nil {
+				m.env = env
 				m.lastUpdate = time.Now()
 			}
 			return m, nil
@@ -116,7 +116,7 @@ func (m *StatusModel) renderProjectInfo() string {
 		TitleStyle.Render("Project Information"),
 	}
 
-	projectPath := m.config.ProjectPath
+	projectPath := m.env.ProjectPath
 	if projectPath == "" {
 		projectPath = "Current directory"
 	}
@@ -129,15 +129,15 @@ func (m *StatusModel) renderProjectInfo() string {
 		fmt.Sprintf("%s %s %s", 
 			Icon("name"), 
 			InfoLabelStyle.Render("Name:"), 
-			InfoValueStyle.Render(m.config.Name)),
+			InfoValueStyle.Render(m.env.Name)),
 		fmt.Sprintf("%s %s %s", 
 			Icon("cluster"), 
 			InfoLabelStyle.Render("Provider:"), 
-			InfoValueStyle.Render(m.config.Provider)),
+			InfoValueStyle.Render(m.env.Provider)),
 		fmt.Sprintf("%s %s %s", 
 			Icon("ttl"), 
 			InfoLabelStyle.Render("TTL:"), 
-			InfoValueStyle.Render(m.config.TTL)),
+			InfoValueStyle.Render(m.env.TTL)),
 	)
 
 	return strings.Join(lines, "\n") + "\n"
@@ -149,8 +149,8 @@ func (m *StatusModel) renderStatusInfo() string {
 	}
 
 	// Status with icon
-	statusIcon := Icon(m.config.Status)
-	if m.config.Status == "" {
+	statusIcon := Icon(m.env.Status)
+	if m.env.Status == "" {
 		statusIcon = Icon("unknown")
 	}
 	
@@ -158,23 +158,23 @@ func (m *StatusModel) renderStatusInfo() string {
 		fmt.Sprintf("%s %s %s", 
 			statusIcon,
 			InfoLabelStyle.Render("Status:"), 
-			FormatStatus(m.config.Status)),
+			FormatStatus(m.env.Status)),
 	)
 
 	// Status-specific information
-	switch m.config.Status {
+	switch m.env.Status {
 	case "active":
-		remaining := m.config.TimeRemaining()
+		remaining := m.env.TimeRemaining()
 		if remaining > 0 {
 			lines = append(lines,
 				fmt.Sprintf("%s %s %s", 
 					Icon("created"), 
 					InfoLabelStyle.Render("Created:"), 
-					InfoValueStyle.Render(m.config.CreatedAt.Format("2006-01-02 15:04:05"))),
+					InfoValueStyle.Render(m.env.CreatedAt.Format("2006-01-02 15:04:05"))),
 				fmt.Sprintf("%s %s %s", 
 					Icon("expires"), 
 					InfoLabelStyle.Render("Expires:"), 
-					InfoValueStyle.Render(m.config.ExpiresAt.Format("2006-01-02 15:04:05"))),
+					InfoValueStyle.Render(m.env.ExpiresAt.Format("2006-01-02 15:04:05"))),
 				fmt.Sprintf("%s %s %s", 
 					Icon("remaining"), 
 					InfoLabelStyle.Render("Remaining:"), 
@@ -188,17 +188,17 @@ func (m *StatusModel) renderStatusInfo() string {
 			lines = append(lines,
 				WarningStyle.Render(fmt.Sprintf("%s Should have been destroyed at: %s", 
 					Icon("warning"),
-					m.config.ExpiresAt.Format("2006-01-02 15:04:05"))),
+					m.env.ExpiresAt.Format("2006-01-02 15:04:05"))),
 			)
 		}
 
 	case "destroyed":
-		if !m.config.CreatedAt.IsZero() {
+		if !m.env.CreatedAt.IsZero() {
 			lines = append(lines,
 				fmt.Sprintf("%s %s %s", 
 					Icon("created"), 
 					InfoLabelStyle.Render("Was created:"), 
-					InfoValueStyle.Render(m.config.CreatedAt.Format("2006-01-02 15:04:05"))),
+					InfoValueStyle.Render(m.env.CreatedAt.Format("2006-01-02 15:04:05"))),
 			)
 		}
 
@@ -212,11 +212,11 @@ func (m *StatusModel) renderStatusInfo() string {
 }
 
 func (m *StatusModel) renderProgressBar(remaining time.Duration) string {
-	if m.config.Status != "active" {
+	if m.env.Status != "active" {
 		return ""
 	}
 
-	totalDuration, err := m.config.ParseTTL()
+	totalDuration, err := m.env.Lifetime()
 	if err != nil {
 		return ""
 	}
@@ -47,6 +47,14 @@ var (
 				Foreground(ColorWarning).
 				Bold(true)
 
+	StatusProvisioningStyle = lipgloss.NewStyle().
+				Foreground(ColorSecondary).
+				Bold(true)
+
+	StatusFailedStyle = lipgloss.NewStyle().
+				Foreground(ColorDanger).
+				Bold(true)
+
 	StatusUnknownStyle = lipgloss.NewStyle().
 				Foreground(ColorMuted).
 				Bold(true)
@@ -146,6 +154,14 @@ func FormatStatus(status string) string {
 		return StatusDestroyedStyle.Render("DESTROYED")
 	case "expired":
 		return StatusExpiredStyle.Render("EXPIRED")
+	case "provisioning":
+		return StatusProvisioningStyle.Render("PROVISIONING")
+	case "destroying":
+		return StatusProvisioningStyle.Render("DESTROYING")
+	case "failed":
+		return StatusFailedStyle.Render("FAILED")
+	case "drifted":
+		return StatusExpiredStyle.Render("DRIFTED")
 	default:
 		return StatusUnknownStyle.Render("UNKNOWN")
 	}
@@ -15,15 +15,15 @@ import (
 
 // Monitor represents the monitoring view (watch mode)
 type Monitor struct {
-	config     *config.Config
+	env        *config.Environment
 	width      int
 	height     int
 	lastUpdate time.Time
 	err        error
 	
-	// File monitoring
-	configPath string
-	configStat os.FileInfo
+	// State file monitoring
+	statePath  string
+	stateStat  os.FileInfo
 	
 	// Components
 	header    *components.Header
@@ -33,17 +33,18 @@ type Monitor struct {
 }
 
 // NewMonitorView creates a new monitor view
-func NewMonitorView(cfg *config.Config) View {
-	configStat, _ := os.Stat(".dick.yaml")
+func NewMonitorView(env *config.Environment) View {
+	statePath := config.StatePath(env.ProjectPath)
+	stateStat, _ := os.Stat(statePath)
 	
 	eventLog := components.NewEventLog(20, 5)
 	eventLog.Add(fmt.Sprintf("Started monitoring at %s", time.Now().Format("15:04:05")))
 	
 	return &Monitor{
-		config:     cfg,
+		env:        env,
 		lastUpdate: time.Now(),
-		configPath: ".dick.yaml",
-		configStat: configStat,
+		statePath:  statePath,
+		stateStat:  stateStat,
 		header:     components.NewHeader("Dick Cluster Monitor", "cluster"),
 		footer:     components.NewFooter().SetActiveView(messages.MonitorView),
 		eventLog:   eventLog,
@@ -68,8 +69,8 @@ func (m *Monitor) Update(msg tea.Msg) (View, tea.Cmd) {
 		switch msg.String() {
 		case "r":
 			// Refresh config and add event
-			if cfg, err := config.LoadConfig(); err == nil {
-				m.config = cfg
+			if env, err := config.LoadEnvironment(m.env.Name); err == nil {
+				m.env = env
 				m.eventLog.Add("Config manually refreshed")
 			}
 			return m, nil
@@ -84,27 +85,30 @@ func (m *Monitor) Update(msg tea.Msg) (View, tea.Cmd) {
 		m.lastUpdate = msg.Time
 		m.footer.UpdateTime(msg.Time)
 		
-		// Check for config file changes
-		if stat, err := os.Stat(m.configPath); err == nil {
-			if m.configStat != nil && stat.ModTime().After(m.configStat.ModTime()) {
-				m.eventLog.Add("Config file changed, reloading...")
-				if cfg, err := config.LoadConfig(); err == nil {
-					m.config = cfg
+		// Check for state file changes
+		if stat, err := os.Stat(m.statePath); err == nil {
+			if m.stateStat != nil && stat.ModTime().After(m.stateStat.ModTime()) {
+				m.eventLog.Add("State file changed, reloading...")
+				if env, err := config.LoadEnvironment(m.env.Name); err == nil {
+					if env.ExpiresAt.After(m.env.ExpiresAt) && len(env.Extensions) > len(m.env.Extensions) {
+						m.eventLog.Add(fmt.Sprintf("Expiry extended to %s", env.ExpiresAt.Format("15:04:05")))
+					}
+					m.env = env
 				}
 			}
-			m.configStat = stat
+			m.stateStat = stat
 		}
 		
 		// Update progress bar if active
-		if m.config.Status == "active" {
+		if m.env.Status == "active" {
 			m.updateProgressBar()
 		}
 		
 		return m, nil
 		
 	case messages.ConfigReloadMsg:
-		if cfg, err := config.LoadConfig(); err == nil {
-			m.config = cfg
+		if env, err := config.LoadEnvironment(m.env.Name); err == nil {
+			m.env = env
 			m.eventLog.Add("Config reloaded from external source")
 		}
 		return m, nil
@@ -159,15 +163,18 @@ func (m *Monitor) View() string {
 
 func (m *Monitor) renderStatusSection() string {
 	table := components.NewTable("Live Status")
-	table.AddRow("Name", m.config.Name, "")
-	table.AddRow("Provider", m.config.Provider, "")
-	table.AddRow("Status", styles.FormatStatus(m.config.Status), "")
+	table.AddRow("Name", m.env.Name, "")
+	table.AddRow("Provider", m.env.Provider, "")
+	table.AddRow("Status", styles.FormatStatus(m.env.Status), "")
+	if len(m.env.Extensions) > 0 {
+		table.AddRow("Extended", fmt.Sprintf("+%s (%dx)", m.env.ExtendedBy().Round(time.Second), len(m.env.Extensions)), "")
+	}
 	
 	statusLines := []string{table.Render()}
 	
 	// Status-specific info
-	if m.config.Status == "active" {
-		remaining := m.config.TimeRemaining()
+	if m.env.Status == "active" {
+		remaining := m.env.TimeRemaining()
 		if remaining > 0 {
 			table2 := components.NewTable("")
 			table2.AddRow("Remaining", 
@@ -193,44 +200,44 @@ func (m *Monitor) renderStatusSection() string {
 func (m *Monitor) renderDebugSection() string {
 	table := components.NewTable("Debug Information")
 	
-	// Config file info
-	if m.configStat != nil {
-		table.AddRow("Config file", m.configPath, "")
+	// State file info
+	if m.stateStat != nil {
+		table.AddRow("State file", m.statePath, "")
 		table.AddRow("Last modified", 
-			m.configStat.ModTime().Format("15:04:05"), "")
+			m.stateStat.ModTime().Format("15:04:05"), "")
 	}
 	
 	// Scheduled job info
-	if m.config.ScheduledJobID != "" {
-		table.AddRow("Job ID", m.config.ScheduledJobID, "")
+	if m.env.ScheduledJobID != "" {
+		table.AddRow("Job ID", m.env.ScheduledJobID, "")
 	}
 	
 	// Timestamps
-	if !m.config.CreatedAt.IsZero() {
+	if !m.env.CreatedAt.IsZero() {
 		table.AddRow("Created", 
-			m.config.CreatedAt.Format("15:04:05"), "")
+			m.env.CreatedAt.Format("15:04:05"), "")
 	}
-	if !m.config.ExpiresAt.IsZero() {
+	if !m.env.ExpiresAt.IsZero() {
 		table.AddRow("Expires", 
-			m.config.ExpiresAt.Format("15:04:05"), "")
+			m.env.ExpiresAt.Format("15:04:05"), "")
 	}
 	
 	return table.Render() + "\n"
 }
 
 func (m *Monitor) updateProgressBar() {
-	if m.config.Status != "active" {
+	if m.env.Status != "active" {
 		m.progress = nil
 		return
 	}
 	
-	totalDuration, err := m.config.ParseTTL()
+	totalDuration, err := m.env.Lifetime()
 	if err != nil {
 		m.progress = nil
 		return
 	}
 	
-	remaining := m.config.TimeRemaining()
+	remaining := m.env.TimeRemaining()
 	elapsed := totalDuration - remaining
 	
 	if m.progress == nil {
@@ -14,7 +14,7 @@ import (
 
 // Status represents the status view
 type Status struct {
-	config     *config.Config
+	env        *config.Environment
 	width      int
 	height     int
 	lastUpdate time.Time
@@ -27,9 +27,9 @@ type Status struct {
 }
 
 // NewStatusView creates a new status view
-func NewStatusView(cfg *config.Config) View {
+func NewStatusView(env *config.Environment) View {
 	return &Status{
-		config:     cfg,
+		env:        env,
 		lastUpdate: time.Now(),
 		header:     components.NewHeader("Dick Cluster Status", "cluster"),
 		footer:     components.NewFooter().SetActiveView(messages.StatusView),
@@ -55,8 +55,8 @@ func (s *Status) Update(msg tea.Msg) (View, tea.Cmd) {
 		switch msg.String() {
 		case "r":
 			// Refresh config
-			if cfg, err := config.LoadConfig(); err == nil {
-				s.config = cfg
+			if env, err := config.LoadEnvironment(s.env.Name); err == nil {
+				s.env = env
 				s.lastUpdate = time.Now()
 			}
 			return s, nil
@@ -67,14 +67,14 @@ func (s *Status) Update(msg tea.Msg) (View, tea.Cmd) {
 		s.footer.UpdateTime(msg.Time)
 		
 		// Update progress bar if active
-		if s.config.Status == "active" {
+		if s.env.Status == "active" {
 			s.updateProgressBar()
 		}
 		return s, nil
 		
 	case messages.ConfigReloadMsg:
-		if cfg, err := config.LoadConfig(); err == nil {
-			s.config = cfg
+		if env, err := config.LoadEnvironment(s.env.Name); err == nil {
+			s.env = env
 		}
 		return s, nil
 		
@@ -119,16 +119,16 @@ func (s *Status) View() string {
 }
 
 func (s *Status) renderProjectInfo() string {
-	projectPath := s.config.ProjectPath
+	projectPath := s.env.ProjectPath
 	if projectPath == "" {
 		projectPath = "Current directory"
 	}
 	
 	table := components.NewTable("Project Information")
 	table.AddRow("Project", projectPath, styles.Icon("project"))
-	table.AddRow("Name", s.config.Name, styles.Icon("name"))
-	table.AddRow("Provider", s.config.Provider, styles.Icon("cluster"))
-	table.AddRow("TTL", s.config.TTL, styles.Icon("ttl"))
+	table.AddRow("Name", s.env.Name, styles.Icon("name"))
+	table.AddRow("Provider", s.env.Provider, styles.Icon("cluster"))
+	table.AddRow("TTL", s.env.TTL, styles.Icon("ttl"))
 	
 	return table.Render() + "\n"
 }
@@ -139,8 +139,8 @@ func (s *Status) renderStatusInfo() string {
 	}
 	
 	// Status with icon
-	statusIcon := styles.Icon(s.config.Status)
-	if s.config.Status == "" {
+	statusIcon := styles.Icon(s.env.Status)
+	if s.env.Status == "" {
 		statusIcon = styles.Icon("unknown")
 	}
 	
@@ -148,23 +148,23 @@ func (s *Status) renderStatusInfo() string {
 		fmt.Sprintf("%s %s %s", 
 			statusIcon,
 			styles.InfoLabelStyle.Render("Status:"), 
-			styles.FormatStatus(s.config.Status)),
+			styles.FormatStatus(s.env.Status)),
 	)
 	
 	// Status-specific information
-	switch s.config.Status {
+	switch s.env.Status {
 	case "active":
-		remaining := s.config.TimeRemaining()
+		remaining := s.env.TimeRemaining()
 		if remaining > 0 {
 			lines = append(lines,
 				fmt.Sprintf("%s %s %s", 
 					styles.Icon("created"), 
 					styles.InfoLabelStyle.Render("Created:"), 
-					styles.InfoValueStyle.Render(s.config.CreatedAt.Format("2006-01-02 15:04:05"))),
+					styles.InfoValueStyle.Render(s.env.CreatedAt.Format("2006-01-02 15:04:05"))),
 				fmt.Sprintf("%s %s %s", 
 					styles.Icon("expires"), 
 					styles.InfoLabelStyle.Render("Expires:"), 
-					styles.InfoValueStyle.Render(s.config.ExpiresAt.Format("2006-01-02 15:04:05"))),
+					styles.InfoValueStyle.Render(s.env.ExpiresAt.Format("2006-01-02 15:04:05"))),
 				fmt.Sprintf("%s %s %s", 
 					styles.Icon("remaining"), 
 					styles.InfoLabelStyle.Render("Remaining:"), 
@@ -179,17 +179,17 @@ func (s *Status) renderStatusInfo() string {
 			lines = append(lines,
 				styles.WarningStyle.Render(fmt.Sprintf("%s Should have been destroyed at: %s", 
 					styles.Icon("warning"),
-					s.config.ExpiresAt.Format("2006-01-02 15:04:05"))),
+					s.env.ExpiresAt.Format("2006-01-02 15:04:05"))),
 			)
 		}
 		
 	case "destroyed":
-		if !s.config.CreatedAt.IsZero() {
+		if !s.env.CreatedAt.IsZero() {
 			lines = append(lines,
 				fmt.Sprintf("%s %s %s", 
 					styles.Icon("created"), 
 					styles.InfoLabelStyle.Render("Was created:"), 
-					styles.InfoValueStyle.Render(s.config.CreatedAt.Format("2006-01-02 15:04:05"))),
+					styles.InfoValueStyle.Render(s.env.CreatedAt.Format("2006-01-02 15:04:05"))),
 			)
 		}
 		
@@ -203,18 +203,18 @@ func (s *Status) renderStatusInfo() string {
 }
 
 func (s *Status) updateProgressBar() {
-	if s.config.Status != "active" {
+	if s.env.Status != "active" {
 		s.progress = nil
 		return
 	}
 	
-	totalDuration, err := s.config.ParseTTL()
+	totalDuration, err := s.env.Lifetime()
 	if err != nil {
 		s.progress = nil
 		return
 	}
 	
-	remaining := s.config.TimeRemaining()
+	remaining := s.env.TimeRemaining()
 	elapsed := totalDuration - remaining
 	
 	if s.progress == nil {
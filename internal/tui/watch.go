@@ -12,28 +12,29 @@ import (
 
 // WatchModel represents the watch monitoring TUI model
 type WatchModel struct {
-	config       *config.Config
+	env          *config.Environment
 	width        int
 	height       int
 	lastUpdate   time.Time
 	err          error
 	refreshRate  time.Duration
 	events       []string
-	configPath   string
-	configStat   os.FileInfo
+	statePath    string
+	stateStat    os.FileInfo
 }
 
 // NewWatchModel creates a new watch TUI model
-func NewWatchModel(cfg *config.Config) *WatchModel {
-	configStat, _ := os.Stat(".dick.yaml")
+func NewWatchModel(env *config.Environment) *WatchModel {
+	statePath := config.StatePath(env.ProjectPath)
+	stateStat, _ := os.Stat(statePath)
 	
 	return &WatchModel{
-		config:      cfg,
+		env:         env,
 		refreshRate: time.Second,
 		lastUpdate:  time.Now(),
 		events:      []string{fmt.Sprintf("Started monitoring at %s", time.Now().Format("15:04:05"))},
-		configPath:  ".dick.yaml",
-		configStat:  configStat,
+		statePath:   statePath,
+		stateStat:   stateStat,
 	}
 }
 
@@ -59,8 +60,8 @@ func (m *WatchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Quit
 		case "r":
 			// Refresh config and add event
-			if cfg, err := config.LoadConfig(); err == nil {
-				m.config = cfg
+			if env, err := config.LoadEnvironment(m.env.Name); err == nil {
+				m.env = env
 				m.addEvent("Config manually refreshed")
 			}
 			return m, nil
@@ -73,15 +74,18 @@ func (m *WatchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tickMsg:
 		m.lastUpdate = time.Now()
 		
-		// Check for config file changes
-		if stat, err := os.Stat(m.configPath); err == nil {
-			if m.configStat != nil && stat.ModTime().After(m.configStat.ModTime()) {
-				m.addEvent("Config file changed, reloading...")
-				if cfg, err := config.LoadConfig(); err == nil {
-					m.config = cfg
+		// Check for state file changes
+		if stat, err := os.Stat(m.statePath); err == nil {
+			if m.stateStat != nil && stat.ModTime().After(m.stateStat.ModTime()) {
+				m.addEvent("State file changed, reloading...")
+				if env, err := config.LoadEnvironment(m.env.Name); err == nil {
+					if env.ExpiresAt.After(m.env.ExpiresAt) && len(env.Extensions) > len(m.env.Extensions) {
+						m.addEvent(fmt.Sprintf("Expiry extended to %s", env.ExpiresAt.Format("15:04:05")))
+					}
+					m.env = env
 				}
 			}
-			m.configStat = stat
+			m.stateStat = stat
 		}
 		
 		return m, m.tick()
@@ -139,14 +143,14 @@ func (m *WatchModel) renderStatusSection() string {
 
 	// Basic info
 	lines = append(lines,
-		fmt.Sprintf("%s %s", InfoLabelStyle.Render("Name:"), InfoValueStyle.Render(m.config.Name)),
-		fmt.Sprintf("%s %s", InfoLabelStyle.Render("Provider:"), InfoValueStyle.Render(m.config.Provider)),
-		fmt.Sprintf("%s %s", InfoLabelStyle.Render("Status:"), FormatStatus(m.config.Status)),
+		fmt.Sprintf("%s %s", InfoLabelStyle.Render("Name:"), InfoValueStyle.Render(m.env.Name)),
+		fmt.Sprintf("%s %s", InfoLabelStyle.Render("Provider:"), InfoValueStyle.Render(m.env.Provider)),
+		fmt.Sprintf("%s %s", InfoLabelStyle.Render("Status:"), FormatStatus(m.env.Status)),
 	)
 
 	// Status-specific info
-	if m.config.Status == "active" {
-		remaining := m.config.TimeRemaining()
+	if m.env.Status == "active" {
+		remaining := m.env.TimeRemaining()
 		if remaining > 0 {
 			lines = append(lines,
 				fmt.Sprintf("%s %s", InfoLabelStyle.Render("Remaining:"), 
@@ -175,33 +179,33 @@ func (m *WatchModel) renderDebugSection() string {
 		TitleStyle.Render("🔧 Debug Information"),
 	}
 
-	// Config file info
-	if m.configStat != nil {
+	// State file info
+	if m.stateStat != nil {
 		lines = append(lines,
-			fmt.Sprintf("%s %s", InfoLabelStyle.Render("Config file:"), InfoValueStyle.Render(m.configPath)),
+			fmt.Sprintf("%s %s", InfoLabelStyle.Render("State file:"), InfoValueStyle.Render(m.statePath)),
 			fmt.Sprintf("%s %s", InfoLabelStyle.Render("Last modified:"), 
-				InfoValueStyle.Render(m.configStat.ModTime().Format("15:04:05"))),
+				InfoValueStyle.Render(m.stateStat.ModTime().Format("15:04:05"))),
 		)
 	}
 
 	// Scheduled job info
-	if m.config.ScheduledJobID != "" {
+	if m.env.ScheduledJobID != "" {
 		lines = append(lines,
-			fmt.Sprintf("%s %s", InfoLabelStyle.Render("Job ID:"), InfoValueStyle.Render(m.config.ScheduledJobID)),
+			fmt.Sprintf("%s %s", InfoLabelStyle.Render("Job ID:"), InfoValueStyle.Render(m.env.ScheduledJobID)),
 		)
 	}
 
 	// Timestamps
-	if !m.config.CreatedAt.IsZero() {
+	if !m.env.CreatedAt.IsZero() {
 		lines = append(lines,
 			fmt.Sprintf("%s %s", InfoLabelStyle.Render("Created:"), 
-				InfoValueStyle.Render(m.config.CreatedAt.Format("15:04:05"))),
+				InfoValueStyle.Render(m.env.CreatedAt.Format("15:04:05"))),
 		)
 	}
-	if !m.config.ExpiresAt.IsZero() {
+	if !m.env.ExpiresAt.IsZero() {
 		lines = append(lines,
 			fmt.Sprintf("%s %s", InfoLabelStyle.Render("Expires:"), 
-				InfoValueStyle.Render(m.config.ExpiresAt.Format("15:04:05"))),
+				InfoValueStyle.Render(m.env.ExpiresAt.Format("15:04:05"))),
 		)
 	}
 
@@ -243,11 +247,11 @@ func (m *WatchModel) renderControlsSection() string {
 }
 
 func (m *WatchModel) renderProgressBar(remaining time.Duration) string {
-	if m.config.Status != "active" {
+	if m.env.Status != "active" {
 		return ""
 	}
 
-	totalDuration, err := m.config.ParseTTL()
+	totalDuration, err := m.env.Lifetime()
 	if err != nil {
 		return ""
 	}
@@ -0,0 +1,228 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbletea"
+)
+
+// ErrWizardCancelled is returned when the user leaves a wizard before finishing it
+var ErrWizardCancelled = errors.New("cancelled")
+
+// WizardOption is a choice of a multiple choice wizard field
+type WizardOption struct {
+	Value       string
+	Description string
+}
+
+// WizardField is a single question of a wizard. Fields with options are
+// multiple choice, the others take free text.
+type WizardField struct {
+	Key      string
+	Title    string
+	Help     string
+	Options  []WizardOption
+	Value    string
+	Validate func(value string) error
+}
+
+// WizardModel asks a series of questions one at a time
+type WizardModel struct {
+	title     string
+	fields    []*WizardField
+	current   int
+	cursor    int
+	input     string
+	err       error
+	cancelled bool
+	done      bool
+	width     int
+}
+
+// NewWizardModel creates a wizard. Field values are used as defaults.
+func NewWizardModel(title string, fields []*WizardField) *WizardModel {
+	m := &WizardModel{
+		title:  title,
+		fields: fields,
+	}
+	m.enterField()
+	return m
+}
+
+// Init initializes the wizard
+func (m *WizardModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles input for the wizard
+func (m *WizardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		return m, nil
+
+	case tea.KeyMsg:
+		field := m.fields[m.current]
+
+		switch msg.Type {
+		case tea.KeyCtrlC, tea.KeyEsc:
+			m.cancelled = true
+			return m, tea.Quit
+
+		case tea.KeyShiftTab:
+			// Go back to the previous question
+			if m.current > 0 {
+				m.current--
+				m.enterField()
+			}
+			return m, nil
+
+		case tea.KeyEnter:
+			value := m.input
+			if len(field.Options) > 0 {
+				value = field.Options[m.cursor].Value
+			}
+			if field.Validate != nil {
+				if err := field.Validate(value); err != nil {
+					m.err = err
+					return m, nil
+				}
+			}
+
+			field.Value = value
+			m.current++
+			if m.current == len(m.fields) {
+				m.done = true
+				return m, tea.Quit
+			}
+			m.enterField()
+			return m, nil
+		}
+
+		if len(field.Options) > 0 {
+			switch msg.String() {
+			case "up", "k":
+				m.cursor = (m.cursor + len(field.Options) - 1) % len(field.Options)
+			case "down", "j", "tab":
+				m.cursor = (m.cursor + 1) % len(field.Options)
+			}
+			return m, nil
+		}
+
+		switch msg.Type {
+		case tea.KeyBackspace:
+			if runes := []rune(m.input); len(runes) > 0 {
+				m.input = string(runes[:len(runes)-1])
+			}
+		case tea.KeyRunes, tea.KeySpace:
+			m.input += string(msg.Runes)
+		}
+		m.err = nil
+	}
+
+	return m, nil
+}
+
+// enterField prepares the current field for input, starting from its value
+func (m *WizardModel) enterField() {
+	field := m.fields[m.current]
+	m.err = nil
+	m.input = field.Value
+	m.cursor = 0
+	for i, option := range field.Options {
+		if option.Value == field.Value {
+			m.cursor = i
+		}
+	}
+}
+
+// View renders the wizard
+func (m *WizardModel) View() string {
+	if m.done || m.cancelled {
+		return ""
+	}
+	if m.width == 0 {
+		m.width = 70 // Default width
+	}
+
+	var sections []string
+
+	// Title and progress
+	sections = append(sections, HeaderStyle.Render(m.title))
+	sections = append(sections, ProgressTextStyle.Render(
+		fmt.Sprintf("Step %d of %d", m.current+1, len(m.fields))))
+
+	// Answers so far
+	for _, field := range m.fields[:m.current] {
+		sections = append(sections, fmt.Sprintf("%s %s %s",
+			SuccessStyle.Render("✓"),
+			InfoLabelStyle.Render(field.Title+":"),
+			InfoValueStyle.Render(field.Value)))
+	}
+
+	// Current question
+	field := m.fields[m.current]
+	sections = append(sections, "", TitleStyle.Render(" "+field.Title+" "))
+	if field.Help != "" {
+		sections = append(sections, ProgressTextStyle.Render(field.Help))
+	}
+
+	if len(field.Options) > 0 {
+		for i, option := range field.Options {
+			line := fmt.Sprintf("  %s  %s", option.Value, ProgressTextStyle.Render(option.Description))
+			if i == m.cursor {
+				line = fmt.Sprintf("%s %s  %s", InfoValueStyle.Render(">"),
+					ButtonSelectedStyle.Render(option.Value), ProgressTextStyle.Render(option.Description))
+			}
+			sections = append(sections, line)
+		}
+	} else {
+		sections = append(sections, fmt.Sprintf("%s %s█", InfoValueStyle.Render(">"), m.input))
+	}
+
+	if m.err != nil {
+		sections = append(sections, ErrorStyle.Render(m.err.Error()))
+	}
+
+	// Instructions
+	instructions := []string{"Confirm: Enter", "Back: Shift+Tab", "Cancel: Esc"}
+	if len(field.Options) > 0 {
+		instructions = append([]string{"Choose: ↑ ↓"}, instructions...)
+	}
+	sections = append(sections, "", ProgressTextStyle.Render(strings.Join(instructions, " • ")))
+
+	return BorderStyle.Width(m.width - 4).Render(strings.Join(sections, "\n"))
+}
+
+// Values returns the answers keyed by field key
+func (m *WizardModel) Values() map[string]string {
+	values := make(map[string]string, len(m.fields))
+	for _, field := range m.fields {
+		values[field.Key] = field.Value
+	}
+	return values
+}
+
+// RunWizard asks the fields one at a time and returns the answers keyed by
+// field key, or ErrWizardCancelled if the user gave up
+func RunWizard(title string, fields []*WizardField) (map[string]string, error) {
+	model := NewWizardModel(title, fields)
+	p := tea.NewProgram(model)
+
+	finalModel, err := p.Run()
+	if err != nil {
+		return nil, err
+	}
+
+	wizardModel, ok := finalModel.(*WizardModel)
+	if !ok {
+		return nil, fmt.Errorf("unexpected model type")
+	}
+	if !wizardModel.done {
+		return nil, ErrWizardCancelled
+	}
+
+	return wizardModel.Values(), nil
+}
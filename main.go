@@ -5,14 +5,21 @@ Copyright © 2025 NAME HERE <EMAIL ADDRESS>
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	
 	"github.com/killallgit/dick/cmd"
+	"github.com/killallgit/dick/internal/commands"
 )
 
 func main() {
 	if err := cmd.Execute(); err != nil {
+		// Exit with the status a command asked for, e.g. 'dick run' or 'dick wait'
+		var exitErr *commands.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.Code)
+		}
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}